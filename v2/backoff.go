@@ -0,0 +1,87 @@
+package is
+
+import (
+	"math/rand"
+	"time"
+)
+
+// defaultPollInterval is the delay between calls of polling assertions,
+// unless another strategy is set with Backoff.
+const defaultPollInterval = 100 * time.Millisecond
+
+// Backoff returns the delay before the next call of the function polled by
+// an assertion, after the provided number of failed attempts, starting at
+// 0.
+type Backoff func(attempt int) time.Duration
+
+// ConstantBackoff returns a Backoff which always waits d.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff returns a Backoff which waits initial, then doubles
+// the delay after each attempt, up to maxDelay. Each delay is randomly spread by
+// up to the jitter fraction of it, so jitter 0.1 gives delays within 10% of
+// the nominal one.
+func ExponentialBackoff(initial time.Duration, maxDelay time.Duration, jitter float64) Backoff {
+	return func(attempt int) time.Duration {
+		d := initial
+		for i := 0; i < attempt && d < maxDelay; i++ {
+			d *= 2
+		}
+		if d > maxDelay {
+			d = maxDelay
+		}
+		if jitter > 0 {
+			d += time.Duration(float64(d) * jitter * (2*rand.Float64() - 1))
+		}
+		return d
+	}
+}
+
+// Backoff sets the strategy used by polling assertions, such as
+// WaitForTrue and WaitForNoError, to wait between calls. For example:
+//
+//	is.Backoff(is.ExponentialBackoff(time.Millisecond, time.Second, 0.1)).
+//		WaitForTrue(time.Minute, ready)
+func (is *Is) Backoff(b Backoff) *Is {
+	newIs := *is
+	newIs.backoff = b
+	return &newIs
+}
+
+// pollBackoff returns a constant Backoff of interval if it is not 0, or the
+// strategy set with Backoff, or the default one.
+func (is *Is) pollBackoff(interval time.Duration) Backoff {
+	switch {
+	case interval > 0:
+		return ConstantBackoff(interval)
+	case is.backoff != nil:
+		return is.backoff
+	}
+	return ConstantBackoff(defaultPollInterval)
+}
+
+// poll calls cond, waiting between calls as set by backoff, until it
+// returns true or timeout is reached. It reports whether cond returned
+// true. cond is called at least once, and once more when timeout is
+// reached.
+func poll(timeout time.Duration, backoff Backoff, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for attempt := 0; ; attempt++ {
+		if cond() {
+			return true
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false
+		}
+		d := backoff(attempt)
+		if d > remaining {
+			d = remaining
+		}
+		time.Sleep(d)
+	}
+}
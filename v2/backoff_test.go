@@ -0,0 +1,46 @@
+package is
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	is := New(t)
+
+	is.Equal(ConstantBackoff(time.Second)(5), time.Second)
+
+	exp := ExponentialBackoff(time.Millisecond, 10*time.Millisecond, 0)
+	var delays []time.Duration
+	for i := 0; i < 6; i++ {
+		delays = append(delays, exp(i))
+	}
+	is.Equal(delays, []time.Duration{
+		time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond,
+		8 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond,
+	})
+	jittered := ExponentialBackoff(100*time.Millisecond, time.Second, 0.1)
+	for i := 0; i < 100; i++ {
+		d := jittered(0)
+		is.True(d >= 90*time.Millisecond && d <= 110*time.Millisecond)
+	}
+
+	var attempts []int
+	calls := 0
+	custom := is.Backoff(func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return time.Millisecond
+	})
+	custom.WaitForTrue(time.Second, func() bool {
+		calls++
+		return calls == 4
+	})
+	is.Equal(attempts, []int{0, 1, 2})
+
+	start := time.Now()
+	ok := poll(30*time.Millisecond, ConstantBackoff(time.Hour), func() bool {
+		return false
+	})
+	is.False(ok)
+	is.True(time.Since(start) < time.Second)
+}
@@ -0,0 +1,78 @@
+package is
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// budgetSpan is the time spent until an assertion of a block run with
+// WithinBudget.
+type budgetSpan struct {
+	assertion string
+	caller    string
+	elapsed   time.Duration
+}
+
+// budgetTracker records the time spent between the assertions of a block
+// run with WithinBudget.
+type budgetTracker struct {
+	mu    sync.Mutex
+	last  time.Time
+	spans []budgetSpan
+}
+
+// mark records the time spent since the previous assertion, or since the
+// start of the block, until the assertion with the provided name and
+// caller.
+func (b *budgetTracker) mark(assertion string, caller string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.spans = append(b.spans, budgetSpan{
+		assertion: assertion,
+		caller:    caller,
+		elapsed:   now.Sub(b.last),
+	})
+	b.last = now
+}
+
+// WithinBudget calls fn with a copy of this Is, and fails if the block,
+// including its assertions, takes longer than d to run. The failure
+// message lists the time spent until each assertion of the block, to show
+// where time went. This is useful for enforcing latency budgets in
+// integration tests:
+//
+//	is.WithinBudget(200*time.Millisecond, func(is *is.Is) {
+//		resp := get(t, url)
+//		is.Equal(resp.StatusCode, 200)
+//	})
+func (is *Is) WithinBudget(d time.Duration, fn func(is *Is)) bool {
+	is.TB.Helper()
+	b := &budgetTracker{}
+	newIs := *is
+	newIs.budget = b
+	start := time.Now()
+	b.last = start
+	fn(&newIs)
+	end := time.Now()
+	elapsed := end.Sub(start)
+	if elapsed > d {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		var spent strings.Builder
+		for _, span := range b.spans {
+			fmt.Fprintf(&spent, "\n\t%v until %s", span.elapsed, span.assertion)
+			if span.caller != "" {
+				fmt.Fprintf(&spent, " at %s", span.caller)
+			}
+		}
+		if len(b.spans) > 0 {
+			fmt.Fprintf(&spent, "\n\t%v after the last assertion", end.Sub(b.last))
+		}
+		is.fail("block took %v, exceeding the budget of %v%s", elapsed, d, spent.String())
+		return false
+	}
+	return passed(is)
+}
@@ -0,0 +1,27 @@
+package is
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithinBudget(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	is.True(c.WithinBudget(time.Minute, func(c *Is) {
+		c.True(true)
+	}))
+	is.NotErr(c.Err())
+
+	is.False(c.WithinBudget(time.Millisecond, func(c *Is) {
+		c.True(true)
+		time.Sleep(5 * time.Millisecond)
+		c.Equal(1, 2)
+	}))
+	is.ErrCount(c.Err(), 2)
+	is.Matches(c.Errs()[1].Error(), `^\[WithinBudget\] block took .+, exceeding the budget of 1ms
+	.+ until True at .+/budget_test\.go:\d+
+	.+ until Equal at .+/budget_test\.go:\d+
+	.+ after the last assertion$`)
+}
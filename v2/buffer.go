@@ -0,0 +1,83 @@
+package is
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// outputBuffers holds the outputBuffer of each testing.TB with buffered
+// failures.
+var outputBuffers sync.Map
+
+// outputBuffer holds the failure messages of a test until its end.
+type outputBuffer struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+// BufferOutput returns a copy of this instance of Is which holds back the
+// messages of non-fatal failures until the end of the test, and reports
+// them together in a single message, each with the location of its
+// assertion. The test is still marked as failed right away. A fatal failure
+// reports the messages held back so far along with its own.
+//
+// This keeps the failures of each subtest together when many parallel
+// subtests fail at once:
+//
+//	t.Run(name, func(t *testing.T) {
+//		t.Parallel()
+//		is := is.New(t).Lax().BufferOutput()
+//		...
+//	})
+func (is *Is) BufferOutput() *Is {
+	newIs := *is
+	newIs.bufferOutput = true
+	return &newIs
+}
+
+// bufferFailure holds back the message of f until the end of the test, or
+// returns false if there is no end of test to wait for.
+func bufferFailure(tb testing.TB, f Failure) bool {
+	if _, ok := tb.(*handlerTB); ok {
+		// There is no end of test outside go test.
+		return false
+	}
+	v, loaded := outputBuffers.LoadOrStore(tb, &outputBuffer{})
+	b := v.(*outputBuffer)
+	if !loaded {
+		tb.Cleanup(func() {
+			if msg := takeBuffered(tb); msg != "" {
+				tb.Error(msg)
+			}
+		})
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgs = append(b.msgs, bufferedMessage(f))
+	return true
+}
+
+// takeBuffered returns the messages held back for tb, one per line, and
+// forgets them.
+func takeBuffered(tb testing.TB) string {
+	v, ok := outputBuffers.LoadAndDelete(tb)
+	if !ok {
+		return ""
+	}
+	b := v.(*outputBuffer)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Join(b.msgs, "\n")
+}
+
+// bufferedMessage returns the message of f prefixed with the file name and
+// line of its assertion, as the testing package does.
+func bufferedMessage(f Failure) string {
+	msg := strings.ReplaceAll(f.Message, "\n", "\n\t")
+	if f.Caller == "" {
+		return msg
+	}
+	return filepath.Base(f.Caller) + ": " + msg
+}
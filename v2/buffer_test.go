@@ -0,0 +1,38 @@
+package is
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2/istest"
+)
+
+func TestBufferOutput(t *testing.T) {
+	is := New(t)
+
+	var failedEarly bool
+	tb := istest.Run(func(tb *istest.TB) {
+		buffered := New(tb).Lax().BufferOutput()
+		buffered.Equal(1, 2)
+		buffered.Msg("id %d", 7).True(false)
+		failedEarly = tb.Failed()
+		is.Len(tb.Errors(), 0)
+	})
+	is.True(failedEarly)
+	errs := tb.Errors()
+	is.Len(errs, 1)
+	is.Equal(errs[0], "buffer_test.go:15: [Equal] got '1' (int). expected '2' (int)\n"+
+		"buffer_test.go:16: [True] expected boolean to be true - id 7")
+
+	tb = istest.Run(func(tb *istest.TB) {
+		buffered := New(tb).BufferOutput()
+		buffered.Lax().Equal(1, 2)
+		buffered.True(false)
+	})
+	is.Len(tb.Errors(), 0)
+	is.Equal(tb.Fatals(), []string{"buffer_test.go:28: [Equal] got '1' (int). expected '2' (int)\n" +
+		"buffer_test.go:29: [True] expected boolean to be true"})
+
+	c := NewCollector()
+	c.BufferOutput().True(false)
+	is.ErrMsg(c.Err(), "[True] expected boolean to be true")
+}
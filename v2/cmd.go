@@ -0,0 +1,198 @@
+package is
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultCmdTimeout is the timeout of commands run by CmdTester, unless set
+// with WithTimeout or CompletesWithin.
+const defaultCmdTimeout = time.Minute
+
+// CmdTester runs an external command and has chainable assertions on its
+// result. It is created with Is.Cmd. The command is run once, by the first
+// assertion, and failure messages include the command line and the full
+// captured standard output and standard error, in addition to any message
+// set on the Is object with Msg or AddMsg.
+type CmdTester struct {
+	is      *Is
+	name    string
+	args    []string
+	dir     string
+	env     []string
+	stdin   io.Reader
+	timeout time.Duration
+
+	ran      bool
+	stdout   string
+	stderr   string
+	exitCode int
+	err      error
+	elapsed  time.Duration
+	timedOut bool
+}
+
+// Cmd returns a CmdTester for the command with the provided name and
+// arguments, for example:
+//
+//	is.Cmd("go", "version").
+//		Succeeds().
+//		StdoutContains("go1.")
+//
+// Assertions respect the strict or lax mode of the Is object.
+func (is *Is) Cmd(name string, args ...string) *CmdTester {
+	return &CmdTester{
+		is:      is.AddMsg("command: %s", strings.Join(append([]string{name}, args...), " ")),
+		name:    name,
+		args:    args,
+		timeout: defaultCmdTimeout,
+	}
+}
+
+// WithDir sets the working directory of the command.
+func (c *CmdTester) WithDir(dir string) *CmdTester {
+	c.dir = dir
+	return c
+}
+
+// WithEnv adds "key=value" environment variables to the environment of the
+// command, which otherwise inherits the environment of the test.
+func (c *CmdTester) WithEnv(env ...string) *CmdTester {
+	c.env = append(c.env, env...)
+	return c
+}
+
+// WithStdin sets the standard input of the command.
+func (c *CmdTester) WithStdin(r io.Reader) *CmdTester {
+	c.stdin = r
+	return c
+}
+
+// WithTimeout sets the duration after which the command is killed.
+func (c *CmdTester) WithTimeout(d time.Duration) *CmdTester {
+	c.timeout = d
+	return c
+}
+
+// run runs the command, unless it already ran.
+func (c *CmdTester) run() {
+	if c.ran {
+		return
+	}
+	c.ran = true
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	cmd.Dir = c.dir
+	if len(c.env) > 0 {
+		cmd.Env = append(cmd.Environ(), c.env...)
+	}
+	cmd.Stdin = c.stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	start := time.Now()
+	c.err = cmd.Run()
+	c.elapsed = time.Since(start)
+	c.stdout, c.stderr = stdout.String(), stderr.String()
+	c.timedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+	c.exitCode = -1
+	if cmd.ProcessState != nil {
+		c.exitCode = cmd.ProcessState.ExitCode()
+	}
+}
+
+// output formats the captured streams for failure messages.
+func (c *CmdTester) output() string {
+	return fmt.Sprintf("\n\tstdout: %q\n\tstderr: %q", c.stdout, c.stderr)
+}
+
+// Stdout runs the command if needed and returns its standard output.
+func (c *CmdTester) Stdout() string {
+	c.run()
+	return c.stdout
+}
+
+// Stderr runs the command if needed and returns its standard error.
+func (c *CmdTester) Stderr() string {
+	c.run()
+	return c.stderr
+}
+
+// Succeeds checks that the command ran and exited with code 0.
+func (c *CmdTester) Succeeds() *CmdTester {
+	c.is.TB.Helper()
+	c.run()
+	switch {
+	case c.timedOut:
+		c.is.fail("expected command to succeed, but it timed out after %v%s", c.timeout, c.output())
+	case c.err != nil:
+		c.is.fail("expected command to succeed, but got: %v%s", c.err, c.output())
+	default:
+		passed(c.is)
+	}
+	return c
+}
+
+// ExitCode checks that the command ran and exited with the provided code.
+func (c *CmdTester) ExitCode(code int) *CmdTester {
+	c.is.TB.Helper()
+	c.run()
+	switch {
+	case c.timedOut:
+		c.is.fail("expected exit code %d, but the command timed out after %v%s", code, c.timeout, c.output())
+	case c.exitCode != code:
+		c.is.fail("expected exit code %d, but got %d (%v)%s", code, c.exitCode, c.err, c.output())
+	default:
+		passed(c.is)
+	}
+	return c
+}
+
+// StdoutContains checks that the standard output of the command contains
+// substr.
+func (c *CmdTester) StdoutContains(substr string) *CmdTester {
+	c.is.TB.Helper()
+	c.run()
+	if !strings.Contains(c.stdout, substr) {
+		c.is.fail("expected stdout to contain %q%s", substr, c.output())
+	} else {
+		passed(c.is)
+	}
+	return c
+}
+
+// StderrContains checks that the standard error of the command contains
+// substr.
+func (c *CmdTester) StderrContains(substr string) *CmdTester {
+	c.is.TB.Helper()
+	c.run()
+	if !strings.Contains(c.stderr, substr) {
+		c.is.fail("expected stderr to contain %q%s", substr, c.output())
+	} else {
+		passed(c.is)
+	}
+	return c
+}
+
+// CompletesWithin checks that the command completes within d. If the
+// command did not run yet, it is killed after d.
+func (c *CmdTester) CompletesWithin(d time.Duration) *CmdTester {
+	c.is.TB.Helper()
+	if !c.ran && d < c.timeout {
+		c.timeout = d
+	}
+	c.run()
+	if c.timedOut || c.elapsed > d {
+		c.is.fail("expected command to complete within %v, but it took %v%s", d, c.elapsed.Round(time.Millisecond), c.output())
+	} else {
+		passed(c.is)
+	}
+	return c
+}
@@ -0,0 +1,63 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCmdHelper is run as a subprocess by the other Cmd tests.
+func TestCmdHelper(t *testing.T) {
+	if os.Getenv("IS_CMD_HELPER") == "" {
+		return
+	}
+	fmt.Print("out:" + os.Getenv("IS_CMD_ARG"))
+	fmt.Fprint(os.Stderr, "err")
+	switch os.Getenv("IS_CMD_ARG") {
+	case "exit3":
+		os.Exit(3)
+	case "sleep":
+		time.Sleep(5 * time.Second)
+	}
+	os.Exit(0)
+}
+
+func helperCmd(is *Is, arg string) *CmdTester {
+	return is.Cmd(os.Args[0], "-test.run=^TestCmdHelper$").
+		WithEnv("IS_CMD_HELPER=1", "IS_CMD_ARG="+arg)
+}
+
+func TestCmd(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	c := helperCmd(is, "ok").
+		Succeeds().
+		ExitCode(0).
+		StdoutContains("out:ok").
+		StderrContains("err").
+		CompletesWithin(time.Minute)
+	is.Equal(c.Stdout(), "out:ok")
+	helperCmd(is, "exit3").ExitCode(3)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	helperCmd(is, "exit3").Succeeds()
+	is.Strict().True(strings.HasPrefix(msg, "expected command to succeed, but got: exit status 3\n\tstdout: \"out:exit3\"\n\tstderr: \"err\""))
+	helperCmd(is, "ok").ExitCode(1).StdoutContains("nope").StderrContains("nope")
+	helperCmd(is, "sleep").CompletesWithin(100 * time.Millisecond)
+	is.Strict().True(strings.HasPrefix(msg, "expected command to complete within 100ms"))
+	is.Cmd("this-command-does-not-exist").Succeeds()
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 6)
+}
@@ -0,0 +1,227 @@
+package is
+
+import (
+	"reflect"
+	"strings"
+)
+
+// containsElement reports whether the container includes elem. Strings
+// contain substrings, slices and arrays contain elements and maps contain
+// keys. ok is false if the container is none of these.
+func containsElement(container interface{}, elem interface{}, opts equalOptions) (found bool, ok bool) {
+	if container == nil {
+		return false, false
+	}
+	v := reflect.ValueOf(container)
+	switch v.Kind() {
+	case reflect.String:
+		s, isString := elem.(string)
+		if !isString {
+			return false, false
+		}
+		return strings.Contains(v.String(), s), true
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if isEqual(v.Index(i).Interface(), elem, opts) {
+				return true, true
+			}
+		}
+		return false, true
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if isEqual(k.Interface(), elem, opts) {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	return false, false
+}
+
+// Contains checks the provided container to determine if it includes elem.
+// Strings contain substrings, slices and arrays contain elements and maps
+// contain keys. Elements and keys are compared like with Equal.
+func (is *Is) Contains(container interface{}, elem interface{}) bool {
+	is.TB.Helper()
+	found, ok := containsElement(container, elem, is.equalOpts)
+	if !ok {
+		is.fail("expected object '%s' to contain '%s', but the object is not one of string, array, slice or map",
+			objectTypeName(container), objectTypeName(elem))
+		return false
+	}
+	if !found {
+		is.fail("expected %s to contain %s", is.formatValue(container), is.formatValue(elem))
+		return false
+	}
+	return passed(is)
+}
+
+// NotContains checks the provided container to determine if it does not
+// include elem. See Contains.
+func (is *Is) NotContains(container interface{}, elem interface{}) bool {
+	is.TB.Helper()
+	found, ok := containsElement(container, elem, is.equalOpts)
+	if !ok {
+		is.fail("expected object '%s' not to contain '%s', but the object is not one of string, array, slice or map",
+			objectTypeName(container), objectTypeName(elem))
+		return false
+	}
+	if found {
+		is.fail("expected %s not to contain %s", is.formatValue(container), is.formatValue(elem))
+		return false
+	}
+	return passed(is)
+}
+
+// listElements returns the elements of a slice or array. ok is false if the
+// object is neither.
+func listElements(o interface{}) (elems []interface{}, ok bool) {
+	if o == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(o)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	elems = make([]interface{}, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+	return elems, true
+}
+
+// unmatchedElements matches each element of a with an equal element of b,
+// and returns the elements of both which are left.
+func unmatchedElements(a []interface{}, b []interface{}, opts equalOptions) (extraA []interface{}, extraB []interface{}) {
+	used := make([]bool, len(b))
+outer:
+	for _, x := range a {
+		for j, y := range b {
+			if !used[j] && isEqual(x, y, opts) {
+				used[j] = true
+				continue outer
+			}
+		}
+		extraA = append(extraA, x)
+	}
+	for j, y := range b {
+		if !used[j] {
+			extraB = append(extraB, y)
+		}
+	}
+	return extraA, extraB
+}
+
+// ElementsMatch checks the provided slices or arrays to determine if they
+// have the same elements, the same number of times, regardless of order.
+func (is *Is) ElementsMatch(actual interface{}, expected interface{}) bool {
+	is.TB.Helper()
+	a, okA := listElements(actual)
+	e, okE := listElements(expected)
+	if !okA || !okE {
+		is.fail("expected objects '%s' and '%s' to be arrays or slices", objectTypeName(actual), objectTypeName(expected))
+		return false
+	}
+	extraA, extraE := unmatchedElements(a, e, is.equalOpts)
+	if len(extraA) > 0 || len(extraE) > 0 {
+		is.fail("expected elements to match, but got unexpected elements %s and missing elements %s",
+			is.formatValue(extraA), is.formatValue(extraE))
+		return false
+	}
+	return passed(is)
+}
+
+// mapElements returns the keys of the map m, sorted as in failure messages,
+// or their values in the same order if values is true. ok is false if m is
+// not a map.
+func mapElements(m interface{}, values bool) (elems []interface{}, ok bool) {
+	if m == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil, false
+	}
+	for _, k := range sortedMapKeys(v) {
+		if values {
+			elems = append(elems, v.MapIndex(k).Interface())
+		} else {
+			elems = append(elems, k.Interface())
+		}
+	}
+	return elems, true
+}
+
+// KeysEqual checks the keys of the provided map to determine if they are the
+// elements of the provided slice or array, regardless of order.
+func (is *Is) KeysEqual(m interface{}, keys interface{}) bool {
+	is.TB.Helper()
+	return is.mapElementsMatch(m, keys, false)
+}
+
+// ValuesMatch checks the values of the provided map to determine if they
+// are the elements of the provided slice or array, the same number of
+// times, regardless of order.
+func (is *Is) ValuesMatch(m interface{}, values interface{}) bool {
+	is.TB.Helper()
+	return is.mapElementsMatch(m, values, true)
+}
+
+func (is *Is) mapElementsMatch(m interface{}, expected interface{}, values bool) bool {
+	is.TB.Helper()
+	what := "keys"
+	if values {
+		what = "values"
+	}
+	a, okA := mapElements(m, values)
+	e, okE := listElements(expected)
+	if !okA || !okE {
+		is.fail("expected objects '%s' and '%s' to be a map and an array or slice", objectTypeName(m), objectTypeName(expected))
+		return false
+	}
+	extraA, extraE := unmatchedElements(a, e, is.equalOpts)
+	if len(extraA) > 0 || len(extraE) > 0 {
+		is.fail("expected map %s to match, but got unexpected %s %s and missing %s %s",
+			what, what, is.formatValue(extraA), what, is.formatValue(extraE))
+		return false
+	}
+	return passed(is)
+}
+
+// Subset checks the provided list to determine if it includes all the
+// elements of subset. For slices and arrays, each element of subset must be
+// equal to a distinct element of list. For maps, each key of subset must be
+// present in list with an equal value.
+func (is *Is) Subset(list interface{}, subset interface{}) bool {
+	is.TB.Helper()
+	if list != nil && subset != nil {
+		lv, sv := reflect.ValueOf(list), reflect.ValueOf(subset)
+		if lv.Kind() == reflect.Map && sv.Kind() == reflect.Map {
+			for _, k := range sortedMapKeys(sv) {
+				v := lv.MapIndex(k)
+				if !v.IsValid() {
+					is.fail("expected %s to contain key %s", is.formatValue(list), is.formatValue(k))
+					return false
+				}
+				if !isEqual(v.Interface(), sv.MapIndex(k).Interface(), is.equalOpts) {
+					is.fail("expected %s to contain %s: %s, but got: %s", is.formatValue(list),
+						is.formatValue(k), is.formatValue(sv.MapIndex(k)), is.formatValue(v))
+					return false
+				}
+			}
+			return passed(is)
+		}
+	}
+	l, okL := listElements(list)
+	s, okS := listElements(subset)
+	if !okL || !okS {
+		is.fail("expected objects '%s' and '%s' to be arrays, slices or maps", objectTypeName(list), objectTypeName(subset))
+		return false
+	}
+	if missing, _ := unmatchedElements(s, l, is.equalOpts); len(missing) > 0 {
+		is.fail("expected %s to contain %s, but it does not contain %s",
+			is.formatValue(list), is.formatValue(subset), is.formatValue(missing))
+		return false
+	}
+	return passed(is)
+}
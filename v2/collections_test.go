@@ -0,0 +1,109 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestContains(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.Contains("hello world", "lo w")
+	is.Contains([]int{1, 2, 3}, 2)
+	is.Contains([2]string{"a", "b"}, "b")
+	is.Contains(map[string]int{"a": 1}, "a")
+	is.NotContains("hello", "x")
+	is.NotContains([]int{1, 2, 3}, 4)
+	is.NotContains(map[string]int{"a": 1}, "b")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Contains([]int{1, 2}, 3)
+	is.Equal(msg, "expected [1 2] to contain 3")
+	is.Contains(42, 4)
+	is.Contains("hello", 1)
+	is.NotContains([]string{"a"}, "a")
+	is.NotContains(nil, "a")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 5)
+}
+
+func TestElementsMatch(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.ElementsMatch([]int{1, 2, 2, 3}, []int{3, 2, 1, 2})
+	is.ElementsMatch([]string{}, [0]string{})
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.ElementsMatch([]int{1, 2, 2}, []int{1, 2, 3})
+	is.Equal(msg, "expected elements to match, but got unexpected elements [2] and missing elements [3]")
+	is.ElementsMatch("abc", []string{"a"})
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+}
+
+func TestKeysEqual(t *testing.T) {
+	is := New(t)
+
+	m := map[string]int{"a": 1, "b": 2, "c": 2}
+	is.KeysEqual(m, []string{"c", "a", "b"})
+	is.ValuesMatch(m, []int{2, 1, 2})
+	is.KeysEqual(map[int]bool{}, []int{})
+
+	c := NewCollector()
+	c.KeysEqual(m, []string{"a", "b", "d"})
+	c.ValuesMatch(m, [3]int{1, 2, 3})
+	c.KeysEqual([]string{"a"}, []string{"a"})
+	errs := c.Errs()
+	is.Len(errs, 3)
+	is.ErrMsg(errs[0], "[KeysEqual] expected map keys to match, but got unexpected keys [c] and missing keys [d]")
+	is.ErrMsg(errs[1], "[ValuesMatch] expected map values to match, but got unexpected values [2] and missing values [3]")
+	is.ErrMsg(errs[2], "[KeysEqual] expected objects '[]string' and '[]string' to be a map and an array or slice")
+}
+
+func TestSubset(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.Subset([]int{1, 2, 3}, []int{3, 1})
+	is.Subset([]int{1, 2, 3}, []int{})
+	is.Subset(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 2})
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Subset([]int{1, 2}, []int{2, 2})
+	is.Equal(msg, "expected [1 2] to contain [2 2], but it does not contain [2]")
+	is.Subset(map[string]int{"a": 1}, map[string]int{"a": 2})
+	is.Equal(msg, `expected map[a:1] to contain a: 2, but got: 1`)
+	is.Subset(map[string]int{"a": 1}, map[string]int{"b": 1})
+	is.Subset(1, []int{1})
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 4)
+}
@@ -0,0 +1,65 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Collector is an Is which records failures instead of reporting them to a
+// testing object. Logs, such as the failures of assertions made with Warn,
+// are recorded separately and are not failures. This allows libraries to expose validation built on the
+// same comparison semantics as the assertions, without a testing.TB.
+//
+// For example:
+//
+//	c := is.NewCollector()
+//	c.NotZero(cfg.Port)
+//	c.Msg("name").NotEmpty(cfg.Name)
+//	return c.Err()
+type Collector struct {
+	*Is
+
+	mu   sync.Mutex
+	errs []error
+	logs []string
+}
+
+// NewCollector creates a new Collector with no failures.
+func NewCollector() *Collector {
+	c := &Collector{}
+	c.Is = New(&handlerTB{handler: c.record, log: c.recordLog}).Lax()
+	return c
+}
+
+func (c *Collector) record(format string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs = append(c.errs, fmt.Errorf(format, args...))
+}
+
+func (c *Collector) recordLog(format string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logs = append(c.logs, fmt.Sprintf(format, args...))
+}
+
+// Errs returns the failures recorded so far, one error per failure.
+func (c *Collector) Errs() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]error(nil), c.errs...)
+}
+
+// Err returns the failures recorded so far joined with errors.Join, or nil
+// if there were none.
+func (c *Collector) Err() error {
+	return errors.Join(c.Errs()...)
+}
+
+// Logs returns the lines logged so far, such as warnings.
+func (c *Collector) Logs() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.logs...)
+}
@@ -0,0 +1,30 @@
+package is
+
+import (
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	is.NotErr(c.Err())
+	is.True(c.Equal(1, 1))
+	is.NotErr(c.Err())
+
+	is.False(c.Equal(1, 2))
+	is.False(c.Msg("name").NotEmpty(""))
+	is.ErrCount(c.Err(), 2)
+	is.ErrMsg(c.Err(), "[Equal] got '1' (int). expected '2' (int)\n[NotEmpty] expected object 'string' not to be empty - name")
+	is.Len(c.Errs(), 2)
+}
+
+func TestCollectorWarn(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	is.False(c.Warn().Equal(1, 2))
+	is.NotErr(c.Err())
+	is.False(c.TB.Failed())
+	is.Equal(c.Logs(), []string{"warning: [Equal] got '1' (int). expected '2' (int)"})
+}
@@ -0,0 +1,699 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// equalOptions changes how values are compared by Equal and the other
+// comparing assertions. The zero value gives the same results as
+// reflect.DeepEqual, apart from registered equality functions.
+type equalOptions struct {
+	equateNaNs              bool
+	distinguishNegativeZero bool
+	equateEmpty             bool
+	ignoreUnexported        bool
+	ignoreFuncsAndChans     bool
+}
+
+// EquateNaNs returns a copy of this instance of Is which treats NaN floats
+// as equal to each other when comparing values, including floats nested in
+// structs, slices and maps, and in Greater, Less and InDelta. By default,
+// as with ==, NaN is not equal to anything, not even itself.
+func (is *Is) EquateNaNs() *Is {
+	newIs := *is
+	newIs.equalOpts.equateNaNs = true
+	return &newIs
+}
+
+// DistinguishNegativeZero returns a copy of this instance of Is which
+// treats -0.0 and 0.0 as different when comparing values, including floats
+// nested in structs, slices and maps, and orders -0.0 before 0.0 in Greater
+// and Less. By default, as with ==, they are equal.
+//
+// It is the opposite of the EquateNegativeZero option which was first
+// proposed: as equal is already the default, the option distinguishes them
+// instead.
+func (is *Is) DistinguishNegativeZero() *Is {
+	newIs := *is
+	newIs.equalOpts.distinguishNegativeZero = true
+	return &newIs
+}
+
+var (
+	equalFuncsMu sync.RWMutex
+	equalFuncs   = map[reflect.Type]func(a, b interface{}) bool{}
+)
+
+func init() {
+	RegisterEqualFunc(func(a, b *big.Int) bool { return a.Cmp(b) == 0 })
+	RegisterEqualFunc(func(a, b *big.Float) bool { return a.Cmp(b) == 0 })
+	RegisterEqualFunc(func(a, b *big.Rat) bool { return a.Cmp(b) == 0 })
+}
+
+// RegisterEqualFunc registers the function used by Equal and the other
+// comparing assertions to compare two values of type T, instead of
+// reflect.DeepEqual. Values of type T are only compared with values of the
+// same type, and the function is not called if either of them is nil.
+//
+// This is useful for types which do not implement Equaler, for example
+// third-party decimal types:
+//
+//	is.RegisterEqualFunc(func(a, b decimal.Decimal) bool { return a.Equal(b) })
+//
+// Comparing *big.Int, *big.Float and *big.Rat by value is registered by
+// default.
+func RegisterEqualFunc[T any](eq func(a, b T) bool) {
+	equalFuncsMu.Lock()
+	defer equalFuncsMu.Unlock()
+	equalFuncs[reflect.TypeOf((*T)(nil)).Elem()] = func(a, b interface{}) bool {
+		return eq(a.(T), b.(T))
+	}
+	customEqualTypes.Range(func(t, _ interface{}) bool {
+		customEqualTypes.Delete(t)
+		return true
+	})
+	structFields.Range(func(t, _ interface{}) bool {
+		structFields.Delete(t)
+		return true
+	})
+}
+
+var (
+	// customEqualTypes caches whether values of a reflect.Type are compared
+	// with an Equal method or a registered function, see hasCustomEqual.
+	customEqualTypes sync.Map
+
+	equalerType = reflect.TypeOf((*Equaler)(nil)).Elem()
+)
+
+// hasCustomEqual reports whether values of type t implement Equaler or have
+// a function registered with RegisterEqualFunc, in which case they are not
+// compared by their contents.
+func hasCustomEqual(t reflect.Type) bool {
+	if custom, ok := customEqualTypes.Load(t); ok {
+		return custom.(bool)
+	}
+	equalFuncsMu.RLock()
+	_, custom := equalFuncs[t]
+	equalFuncsMu.RUnlock()
+	custom = custom || t.Implements(equalerType)
+	customEqualTypes.Store(t, custom)
+	return custom
+}
+
+// isScalarKind reports whether values of kind k are compared by
+// scalarEqual.
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+// registeredEqual compares a and b with the function registered for their
+// type. ok is false if they are of different types or no function is
+// registered.
+func registeredEqual(a, b interface{}) (equal bool, ok bool) {
+	t := reflect.TypeOf(a)
+	if t != reflect.TypeOf(b) {
+		return false, false
+	}
+	equalFuncsMu.RLock()
+	eq, ok := equalFuncs[t]
+	equalFuncsMu.RUnlock()
+	if !ok {
+		return false, false
+	}
+	return eq(a, b), true
+}
+
+// EquateEmpty returns a copy of this instance of Is which treats nil and
+// empty slices, and nil and empty maps, as equal when comparing values,
+// including collections nested in structs, slices and maps. This is useful
+// when values went through a JSON round-trip. Collections must still be of
+// the same type.
+func (is *Is) EquateEmpty() *Is {
+	newIs := *is
+	newIs.equalOpts.equateEmpty = true
+	return &newIs
+}
+
+// IgnoreUnexported returns a copy of this instance of Is which ignores
+// unexported struct fields when comparing values, including structs nested
+// in other values. This allows comparing structs from other packages by
+// their public API only, since their unexported fields are implementation
+// details which may legitimately differ.
+func (is *Is) IgnoreUnexported() *Is {
+	newIs := *is
+	newIs.equalOpts.ignoreUnexported = true
+	return &newIs
+}
+
+// IgnoreFuncsAndChans returns a copy of this instance of Is which ignores
+// func and chan values when comparing values, including struct fields and
+// elements of other values. By default, funcs are only equal if both are
+// nil, and chans are only equal if they are the same channel.
+func (is *Is) IgnoreFuncsAndChans() *Is {
+	newIs := *is
+	newIs.equalOpts.ignoreFuncsAndChans = true
+	return &newIs
+}
+
+// isEmptyCollection reports whether v is a nil or empty slice or map.
+func isEmptyCollection(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// visit is a pair of pointers being compared, or already found equal. The
+// lengths tell apart slices sharing their first element.
+type visit struct {
+	a, b       unsafe.Pointer
+	typ        reflect.Type
+	alen, blen int
+}
+
+// comparer performs a recursive comparison of two values like
+// reflect.DeepEqual does, but calling Equaler and registered equality
+// functions at every level, and honoring equalOptions.
+//
+// Like reflect.DeepEqual, a pair of pointers already being compared is
+// considered equal, so cyclic values do not recurse forever. The path of the
+// first such cycle is recorded for failure messages. Pairs found equal are
+// remembered in equalPairs and not compared again, so that values shared
+// many times, as in a DAG, are compared once.
+type comparer struct {
+	opts       equalOptions
+	format     formatOptions
+	visited    map[visit]bool
+	equalPairs map[visit]bool
+	root       string
+	path       []pathSegment
+	cycle      string
+
+	// redact is set while comparing a field tagged with `is:"redact"`
+	redact bool
+
+	// diffPath and reason describe the first difference found, diffCount
+	// is the number of differences found and moreDiffs describes the next
+	// ones, up to the limit set with MaxDiffs
+	diffPath  string
+	reason    string
+	diffCount int
+	moreDiffs []string
+}
+
+func newComparer(opts equalOptions, fopts formatOptions, root reflect.Type) *comparer {
+	return &comparer{
+		opts:       opts,
+		format:     fopts,
+		visited:    map[visit]bool{},
+		equalPairs: map[visit]bool{},
+		root:       rootName(root),
+	}
+}
+
+// rootName returns the name used for the root of paths to values of type t,
+// which is the name of the type, or of the type it points to, without the
+// package. Unnamed types have no root name. Named pointer types keep their
+// own name, which also stops at types pointing to themselves.
+func rootName(t reflect.Type) string {
+	for t != nil && t.Kind() == reflect.Ptr && t.Name() == "" {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
+
+// pathString returns the current path, such as "Config.Retry.MaxAttempts"
+// or `[2].Labels["app"]`.
+func (c *comparer) pathString() string {
+	var b strings.Builder
+	b.WriteString(c.root)
+	for _, seg := range c.path {
+		b.WriteString(seg.String())
+	}
+	p := b.String()
+	if c.root == "" {
+		p = strings.TrimPrefix(p, ".")
+	}
+	return p
+}
+
+// pathSegment is an element of the path to the values being compared. It
+// is only formatted when a difference is recorded.
+type pathSegment struct {
+	// field is the name of a struct field, key is a map key, and index is
+	// the index in a slice or array if neither is set
+	field string
+	key   reflect.Value
+	index int
+}
+
+func fieldSegment(name string) pathSegment   { return pathSegment{field: name} }
+func keySegment(k reflect.Value) pathSegment { return pathSegment{key: k} }
+func indexSegment(i int) pathSegment         { return pathSegment{index: i} }
+
+// String returns the segment as written in paths, such as ".Name",
+// `["app"]` or "[2]".
+func (seg pathSegment) String() string {
+	switch {
+	case seg.field != "":
+		return "." + seg.field
+	case seg.key.IsValid():
+		return mapKeySegment(seg.key)
+	}
+	return "[" + strconv.Itoa(seg.index) + "]"
+}
+
+// descend compares a and b as the element seg of the current values.
+func (c *comparer) descend(seg pathSegment, a, b reflect.Value) bool {
+	c.path = append(c.path, seg)
+	equal := c.equal(a, b)
+	c.path = c.path[:len(c.path)-1]
+	return equal
+}
+
+// nested reports whether the first difference found is nested inside the
+// compared values, rather than being the values themselves.
+func (c *comparer) nested() bool {
+	return c != nil && c.diffPath != ""
+}
+
+// mismatch returns a description of the first difference found, such as
+// "Config.Retry.MaxAttempts: got 3, want 5". If elements of slices or arrays
+// differ in several places, the first ones are listed on separate lines,
+// along with the total count.
+func (c *comparer) mismatch() string {
+	first := c.diffPath + ": " + c.reason
+	if c.diffCount <= 1 {
+		return first
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d places:\n\t%s", c.diffCount, first)
+	for _, d := range c.moreDiffs {
+		b.WriteString("\n\t")
+		b.WriteString(d)
+	}
+	if more := c.diffCount - 1 - len(c.moreDiffs); more > 0 {
+		fmt.Fprintf(&b, "\n\t... (%d more)", more)
+	}
+	return b.String()
+}
+
+// details returns additional information about the comparison for failure
+// messages, starting with a space, or an empty string.
+func (c *comparer) details() string {
+	if c == nil {
+		return ""
+	}
+	var d string
+	if c.nested() {
+		d += fmt.Sprintf(" (%s)", c.mismatch())
+	} else if c.reason != "" {
+		d += fmt.Sprintf(" (%s)", c.reason)
+	}
+	return d + c.cycleDetails()
+}
+
+// cycleDetails returns where a cycle was detected for failure messages,
+// starting with a space, or an empty string.
+func (c *comparer) cycleDetails() string {
+	if c == nil || c.cycle == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (cycle detected at %s)", c.cycle)
+}
+
+// explain records why the current values differ. Only the reason for the
+// first difference and the descriptions of the next ones, up to the limit
+// set with MaxDiffs, are kept.
+func (c *comparer) explain(format string, args ...interface{}) {
+	c.diffCount++
+	if c.reason == "" {
+		if len(c.path) > 0 {
+			c.diffPath = c.pathString()
+		}
+		c.reason = fmt.Sprintf(format, args...)
+		return
+	}
+	if len(c.moreDiffs)+1 < c.format.diffLimit() {
+		c.moreDiffs = append(c.moreDiffs, c.pathString()+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+// explainValues records that the current values a and b differ. At the
+// root, nothing is recorded, since failure messages show both values.
+func (c *comparer) explainValues(a, b reflect.Value) {
+	if len(c.path) == 0 {
+		return
+	}
+	c.explain("got %s, want %s", c.leafString(a), c.leafString(b))
+}
+
+// fieldTag parses the `is` tag of a struct field. A field tagged with
+// `is:"-"` is skipped when comparing values, and the value of a field tagged
+// with `is:"redact"` is hidden in failure messages.
+func fieldTag(f reflect.StructField) (skip bool, redact bool) {
+	switch f.Tag.Get("is") {
+	case "-":
+		return true, false
+	case "redact":
+		return false, true
+	}
+	return false, false
+}
+
+// leafString renders a value found at a path like the leafString function,
+// unless the value is redacted.
+func (c *comparer) leafString(v reflect.Value) string {
+	if c.redact {
+		return redacted
+	}
+	return leafString(v, c.format)
+}
+
+// leafString renders a value found at a path, quoting strings so that
+// empty strings and whitespace are visible.
+func leafString(v reflect.Value, fopts formatOptions) string {
+	for v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.IsValid() && v.Kind() == reflect.String {
+		return strconv.Quote(v.String())
+	}
+	return fopts.format(v)
+}
+
+// mapKeySegment returns the path segment for the map key k.
+func mapKeySegment(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return "[" + strconv.Quote(k.String()) + "]"
+	}
+	return "[" + formatValue(k) + "]"
+}
+
+func (c *comparer) floatEqual(a, b float64) bool {
+	if c.opts.equateNaNs && math.IsNaN(a) && math.IsNaN(b) {
+		return true
+	}
+	if c.opts.distinguishNegativeZero && a == 0 && b == 0 {
+		return math.Signbit(a) == math.Signbit(b)
+	}
+	return a == b
+}
+
+func (c *comparer) equal(a, b reflect.Value) (eq bool) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			c.explainValues(a, b)
+			return false
+		}
+		return true
+	}
+	if a.Type() != b.Type() {
+		if len(c.path) > 0 {
+			c.explain("got %s (%s), want %s (%s)", c.leafString(a), a.Type(), c.leafString(b), b.Type())
+		}
+		return false
+	}
+	if c.opts.ignoreFuncsAndChans && (a.Kind() == reflect.Func || a.Kind() == reflect.Chan) {
+		return true
+	}
+
+	if a.CanInterface() && b.CanInterface() && hasCustomEqual(a.Type()) {
+		ai, bi := a.Interface(), b.Interface()
+		equal, ok := false, false
+		if e, isEqualer := ai.(Equaler); isEqualer && !isNil(ai) {
+			equal, ok = e.Equal(bi), true
+		} else if !isNil(ai) && !isNil(bi) {
+			equal, ok = registeredEqual(ai, bi)
+		}
+		if ok {
+			if !equal {
+				c.explainValues(a, b)
+			}
+			return equal
+		}
+	}
+
+	if c.opts.equateEmpty && isEmptyCollection(a) && isEmptyCollection(b) {
+		return true
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if a.Kind() != reflect.Ptr && a.IsNil() != b.IsNil() {
+			if len(c.path) > 0 {
+				c.explain("got %s, want %s", c.nilOrEmpty(a), c.nilOrEmpty(b))
+			}
+			return false
+		}
+		if a.UnsafePointer() == b.UnsafePointer() {
+			if a.Kind() != reflect.Slice || a.Len() == b.Len() {
+				return true
+			}
+		}
+		if a.UnsafePointer() != nil && b.UnsafePointer() != nil {
+			v := visit{a: a.UnsafePointer(), b: b.UnsafePointer(), typ: a.Type()}
+			if a.Kind() == reflect.Slice {
+				v.alen, v.blen = a.Len(), b.Len()
+			}
+			if c.equalPairs[v] {
+				return true
+			}
+			if c.visited[v] {
+				if c.cycle == "" {
+					c.cycle = c.pathString()
+				}
+				return true
+			}
+			// Only the pairs being compared are tracked, so that values
+			// shared without forming a cycle are not reported as cycles.
+			c.visited[v] = true
+			defer func() {
+				delete(c.visited, v)
+				if eq {
+					c.equalPairs[v] = true
+				}
+			}()
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Array, reflect.Slice:
+		elem := a.Type().Elem()
+		scalar := isScalarKind(elem.Kind()) && !hasCustomEqual(elem)
+		if scalar && a.Kind() == reflect.Slice && elem.Kind() == reflect.Uint8 &&
+			a.Len() == b.Len() && bytes.Equal(a.Bytes(), b.Bytes()) {
+			return true
+		}
+		// keep comparing after a difference, to report how many elements
+		// differ
+		equal := true
+		n := a.Len()
+		if b.Len() < n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			if scalar && c.scalarEqual(a.Index(i), b.Index(i)) {
+				continue
+			}
+			if !c.descend(indexSegment(i), a.Index(i), b.Index(i)) {
+				equal = false
+			}
+		}
+		if a.Len() != b.Len() {
+			if len(c.path) > 0 {
+				c.explain("got length %d, want %d", a.Len(), b.Len())
+			}
+			return false
+		}
+		return equal
+	case reflect.Map:
+		for _, k := range sortedMapKeys(a) {
+			seg := keySegment(k)
+			bv := b.MapIndex(k)
+			if !bv.IsValid() {
+				c.path = append(c.path, seg)
+				c.explain("got %s, want no such key", c.leafString(a.MapIndex(k)))
+				c.path = c.path[:len(c.path)-1]
+				return false
+			}
+			if !c.descend(seg, a.MapIndex(k), bv) {
+				return false
+			}
+		}
+		if a.Len() != b.Len() {
+			for _, k := range sortedMapKeys(b) {
+				if !a.MapIndex(k).IsValid() {
+					c.path = append(c.path, keySegment(k))
+					c.explain("missing key, want %s", c.leafString(b.MapIndex(k)))
+					c.path = c.path[:len(c.path)-1]
+					break
+				}
+			}
+			return false
+		}
+		return true
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				c.explainValues(a, b)
+				return false
+			}
+			return true
+		}
+		return c.equal(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if hasRenderer(a.Type()) {
+			// Values rendered as a whole, such as time.Time, are reported
+			// as a whole rather than by their internal fields.
+			sub := &comparer{opts: c.opts, format: c.format, visited: c.visited, equalPairs: map[visit]bool{}}
+			sub.opts.ignoreUnexported = false
+			if !sub.structEqual(a, b) {
+				c.explainValues(a, b)
+				return false
+			}
+			return true
+		}
+		return c.structEqual(a, b)
+	case reflect.Func:
+		// like reflect.DeepEqual, funcs are only equal if both are nil
+		if a.IsNil() && b.IsNil() {
+			return true
+		}
+		c.explain("func values can only be compared to nil")
+		return false
+	case reflect.Chan:
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		c.explain("channels are compared by identity")
+		return false
+	}
+
+	if !c.scalarEqual(a, b) {
+		c.explainValues(a, b)
+		return false
+	}
+	return true
+}
+
+// structEqual compares the fields of the structs a and b, of the same type.
+func (c *comparer) structEqual(a, b reflect.Value) bool {
+	for _, f := range structFieldsOf(a.Type()) {
+		if c.opts.ignoreUnexported && !f.exported {
+			continue
+		}
+		if f.scalar && c.scalarEqual(a.Field(f.index), b.Field(f.index)) {
+			continue
+		}
+		wasRedacted := c.redact
+		c.redact = c.redact || f.redact
+		equal := c.descend(fieldSegment(f.name), a.Field(f.index), b.Field(f.index))
+		c.redact = wasRedacted
+		if !equal {
+			return false
+		}
+	}
+	return true
+}
+
+// structField describes a struct field compared by structEqual.
+type structField struct {
+	index    int
+	name     string
+	exported bool
+	redact   bool
+	// scalar is set if the field is compared with scalarEqual
+	scalar bool
+}
+
+// structFields caches the result of structFieldsOf by reflect.Type.
+var structFields sync.Map
+
+// structFieldsOf returns the fields of the struct type t which are not
+// skipped with their `is` tag.
+func structFieldsOf(t reflect.Type) []structField {
+	if fields, ok := structFields.Load(t); ok {
+		return fields.([]structField)
+	}
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		skip, redact := fieldTag(f)
+		if skip {
+			continue
+		}
+		fields = append(fields, structField{
+			index:    i,
+			name:     f.Name,
+			exported: f.IsExported(),
+			redact:   redact,
+			scalar:   isScalarKind(f.Type.Kind()) && !hasCustomEqual(f.Type),
+		})
+	}
+	structFields.Store(t, fields)
+	return fields
+}
+
+// scalarEqual compares values of the same type which are not containers.
+func (c *comparer) scalarEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return c.floatEqual(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		ac, bc := a.Complex(), b.Complex()
+		return c.floatEqual(real(ac), real(bc)) && c.floatEqual(imag(ac), imag(bc))
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+	return false
+}
+
+// nilOrEmpty describes a slice or map which is nil or empty.
+func (c *comparer) nilOrEmpty(v reflect.Value) string {
+	if v.IsNil() {
+		return "nil " + v.Kind().String()
+	}
+	if v.Len() == 0 {
+		return "empty " + v.Kind().String()
+	}
+	if c.redact {
+		return "non-empty " + v.Kind().String()
+	}
+	return c.leafString(v)
+}
+
+// deepEqual reports whether a and b are deeply equal, see comparer. The
+// comparer is returned for failure details.
+func deepEqual(a, b interface{}, opts equalOptions, fopts formatOptions) (bool, *comparer) {
+	c := newComparer(opts, fopts, reflect.TypeOf(a))
+	return c.equal(reflect.ValueOf(a), reflect.ValueOf(b)), c
+}
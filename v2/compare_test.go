@@ -0,0 +1,404 @@
+package is
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type caseInsensitive string
+
+func TestEqualBig(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+
+	// DeepEqual compares internal state, which differs here
+	zero := new(big.Int).Sub(big.NewInt(5), big.NewInt(5))
+	is.Equal(new(big.Int), zero)
+	is.NotEqual(zero, big.NewInt(1))
+	is.Equal(big.NewFloat(1.5), new(big.Float).SetPrec(200).SetFloat64(1.5))
+	is.Equal(big.NewRat(1, 2), big.NewRat(2, 4))
+	is.NotEqual(big.NewRat(1, 2), big.NewRat(1, 3))
+	is.Strict().Equal(hit, 0)
+
+	is.Equal(big.NewInt(1), big.NewInt(2))
+	is.Equal(big.NewRat(1, 2), big.NewInt(1))
+	is.Strict().Equal(hit, 2)
+
+	hit = 0
+	RegisterEqualFunc(func(a, b caseInsensitive) bool {
+		return strings.EqualFold(string(a), string(b))
+	})
+	is.Equal(caseInsensitive("Hello"), caseInsensitive("hELLO"))
+	is.NotEqual(caseInsensitive("Hello"), caseInsensitive("world"))
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 0)
+}
+
+func TestEqualFloatOptions(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+
+	nan := math.NaN()
+	negZero := math.Copysign(0, -1)
+	type point struct {
+		X, Y float64
+	}
+
+	is.Equal(nan, nan)
+	is.Equal([]float64{1, nan}, []float64{1, nan})
+	is.Equal(point{X: nan}, point{X: nan})
+	is.Equal(complex(nan, 0), complex(nan, 0))
+	is.Strict().Equal(hit, 4)
+
+	hit = 0
+	nanIs := is.EquateNaNs()
+	nanIs.Equal(nan, nan)
+	nanIs.Equal(float32(nan), float32(nan))
+	nanIs.Equal([]float64{1, nan}, []float64{1, nan})
+	nanIs.Equal(map[string]point{"a": {X: nan}}, map[string]point{"a": {X: nan}})
+	nanIs.Equal(complex(nan, 0), complex(nan, 0))
+	nanIs.NotEqual(nan, 1.0)
+	nanIs.OneOf(nan, 1.0, nan)
+	is.Strict().Equal(hit, 0)
+
+	is.Equal(negZero, 0.0)
+	is.Equal(point{Y: negZero}, point{})
+	is.Strict().Equal(hit, 0)
+
+	zeroIs := is.DistinguishNegativeZero()
+	zeroIs.NotEqual(negZero, 0.0)
+	zeroIs.NotEqual(point{Y: negZero}, point{})
+	zeroIs.Equal(negZero, negZero)
+	zeroIs.Equal(0.0, 0.0)
+	is.Strict().Equal(hit, 0)
+
+	// the options also apply to the numeric assertions
+	nanIs.InDelta(nan, nan, 0)
+	zeroIs.Greater(0.0, negZero)
+	zeroIs.Less(negZero, 0)
+	zeroIs.InDelta(negZero, 0.0, 0)
+	is.Strict().Equal(hit, 0)
+	is.InDelta(nan, nan, 0)
+	nanIs.Less(1, nan)
+	is.Greater(0.0, negZero)
+	nanIs.Greater(nan, nan)
+	zeroIs.Greater(negZero, negZero)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 5)
+}
+
+func TestEquateEmpty(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+
+	type doc struct {
+		Tags   []string
+		Labels map[string]string
+	}
+	var nilSlice []int
+	var nilMap map[string]int
+
+	is.Equal([]int{}, nilSlice)
+	is.Equal(nilMap, map[string]int{})
+	is.Equal(doc{Tags: []string{}}, doc{Labels: map[string]string{}})
+	is.Strict().Equal(hit, 3)
+
+	hit = 0
+	emptyIs := is.EquateEmpty()
+	emptyIs.Equal([]int{}, nilSlice)
+	emptyIs.Equal(nilSlice, []int{})
+	emptyIs.Equal(nilMap, map[string]int{})
+	emptyIs.Equal(doc{Tags: []string{}}, doc{Labels: map[string]string{}})
+	emptyIs.Equal([][]int{{}}, [][]int{nil})
+	emptyIs.NotEqual([]int{1}, nilSlice)
+	emptyIs.NotEqual([]int64{}, nilSlice)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 0)
+}
+
+type exportedStruct struct {
+	Name  string
+	cache map[string]int
+	Inner *exportedStruct
+}
+
+func TestIgnoreUnexported(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+
+	a := exportedStruct{Name: "a", cache: map[string]int{"x": 1}, Inner: &exportedStruct{Name: "b"}}
+	b := exportedStruct{Name: "a", Inner: &exportedStruct{Name: "b", cache: map[string]int{}}}
+	is.Equal(a, b)
+	is.Strict().Equal(hit, 1)
+
+	hit = 0
+	is.EqualExported(a, b)
+	is.IgnoreUnexported().Equal([]exportedStruct{a}, []exportedStruct{b})
+	b.Inner.Name = "c"
+	is.Strict().Equal(hit, 0)
+	is.EqualExported(a, b)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 1)
+}
+
+func TestEqualFuncsAndChans(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+
+	type handler struct {
+		Name string
+		Fn   func()
+		Ch   chan int
+	}
+	f := func() {}
+	ch := make(chan int)
+
+	is.Equal(handler{Name: "a", Ch: ch}, handler{Name: "a", Ch: ch})
+	is.Equal(handler{Name: "a"}, handler{Name: "a"})
+	is.Strict().Equal(hit, 0)
+
+	is.Equal(f, f)
+	is.Equal(handler{Fn: f}, handler{Fn: f})
+	is.Equal(handler{Ch: ch}, handler{Ch: make(chan int)})
+	is.Strict().Equal(hit, 3)
+
+	_, c := compareObjects(f, f, equalOptions{}, formatOptions{})
+	is.Equal(c.details(), " (func values can only be compared to nil)")
+	_, c = compareObjects(handler{Fn: f}, handler{Fn: f}, equalOptions{}, formatOptions{})
+	is.Equal(c.details(), " (handler.Fn: func values can only be compared to nil)")
+	_, c = compareObjects([]chan int{ch}, []chan int{make(chan int)}, equalOptions{}, formatOptions{})
+	is.Equal(c.details(), " ([0]: channels are compared by identity)")
+
+	hit = 0
+	ignoreIs := is.IgnoreFuncsAndChans()
+	ignoreIs.Equal(handler{Name: "a", Fn: f, Ch: ch}, handler{Name: "a", Fn: func() {}})
+	ignoreIs.NotEqual(handler{Name: "a", Fn: f}, handler{Name: "b", Fn: f})
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 0)
+}
+
+type retryConfig struct {
+	MaxAttempts int
+	Backoff     string
+}
+
+type config struct {
+	Name   string
+	Retry  retryConfig
+	Hosts  []string
+	Labels map[string]interface{}
+}
+
+func TestEqualFieldPath(t *testing.T) {
+	is := New(t)
+
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		msg = fmt.Sprintf(format, args...)
+	})
+	expectMsg := func(want string) {
+		t.Helper()
+		if msg != want {
+			t.Fatalf("got message %q, want %q", msg, want)
+		}
+	}
+
+	base := func() config {
+		return config{
+			Name:   "a",
+			Retry:  retryConfig{MaxAttempts: 5, Backoff: "exp"},
+			Hosts:  []string{"h1", "h2"},
+			Labels: map[string]interface{}{"app": "is", "tier": 1},
+		}
+	}
+	expected := base()
+
+	actual := base()
+	actual.Retry.MaxAttempts = 3
+	is.Equal(actual, expected)
+	expectMsg("objects of type 'is.config' differ at config.Retry.MaxAttempts: got 3, want 5")
+
+	actual = base()
+	actual.Hosts[1] = "h3"
+	is.Equal(&actual, &expected)
+	expectMsg(`objects of type '*is.config' differ at config.Hosts[1]: got "h3", want "h2"`)
+
+	actual = base()
+	actual.Hosts = actual.Hosts[:1]
+	is.Equal(actual, expected)
+	expectMsg("objects of type 'is.config' differ at config.Hosts: got length 1, want 2")
+
+	actual = base()
+	actual.Hosts = nil
+	expected.Hosts = []string{}
+	is.Equal(actual, expected)
+	expectMsg("objects of type 'is.config' differ at config.Hosts: got nil slice, want empty slice")
+	expected = base()
+
+	actual = base()
+	actual.Labels["tier"] = "1"
+	is.Equal(actual, expected)
+	expectMsg(`objects of type 'is.config' differ at config.Labels["tier"]: got "1" (string), want 1 (int)`)
+
+	actual = base()
+	delete(actual.Labels, "app")
+	actual.Labels["zone"] = "eu"
+	is.Equal(actual, expected)
+	expectMsg(`objects of type 'is.config' differ at config.Labels["zone"]: got "eu", want no such key`)
+
+	actual = base()
+	delete(actual.Labels, "app")
+	is.Equal(actual, expected)
+	expectMsg(`objects of type 'is.config' differ at config.Labels["app"]: missing key, want "is"`)
+
+	is.Equal([]int{1, 2, 3}, []int{1, 5, 3})
+	expectMsg("objects of type '[]int' differ at [1]: got 2, want 5")
+
+	is.Equal(1, 2)
+	expectMsg("got '1' (int). expected '2' (int)")
+
+}
+
+type credentials struct {
+	User      string
+	Password  string `is:"redact"`
+	FetchedAt int64  `is:"-"`
+}
+
+func TestEqualStructTags(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+
+	is.Equal(credentials{User: "u", Password: "p", FetchedAt: 1}, credentials{User: "u", Password: "p", FetchedAt: 2})
+	is.Strict().Equal(hit, 0)
+
+	is.Equal(credentials{User: "u", Password: "secret1"}, credentials{User: "u", Password: "secret2"})
+	is.Strict().Equal(hit, 1)
+	if strings.Contains(msg, "secret") {
+		t.Fatalf("redacted value in message: %s", msg)
+	}
+	if msg != "objects of type 'is.credentials' differ at credentials.Password: got <redacted>, want <redacted>" {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+
+	is.Nil(&credentials{User: "u", Password: "secret"})
+	if msg != "expected object '*is.credentials' to be nil, but got: &{User:u Password:<redacted> FetchedAt:0}" {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+}
+
+func TestEqualManyDiffs(t *testing.T) {
+	is := New(t)
+
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		msg = fmt.Sprintf(format, args...)
+	})
+	expectMsg := func(want string) {
+		t.Helper()
+		if msg != want {
+			t.Fatalf("got message %q, want %q", msg, want)
+		}
+	}
+
+	a := make([]int, 1000)
+	b := make([]int, 1000)
+	for i := range b {
+		if i%100 == 7 {
+			b[i] = 1
+		}
+	}
+	is.MaxDiffs(3).Equal(a, b)
+	expectMsg(`objects of type '[]int' differ at 10 places:
+	[7]: got 0, want 1
+	[107]: got 0, want 1
+	[207]: got 0, want 1
+	... (7 more)`)
+
+	is.Equal(a, b)
+	if !strings.HasSuffix(msg, "[907]: got 0, want 1") {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+
+	type row struct {
+		Cells []int
+	}
+	is.Equal([]row{{Cells: []int{1, 2}}, {Cells: []int{3}}}, []row{{Cells: []int{1, 5}}, {Cells: []int{3, 4}}})
+	expectMsg(`objects of type '[]is.row' differ at 2 places:
+	[0].Cells[1]: got 2, want 5
+	[1].Cells: got length 1, want 2`)
+}
+
+func TestEqualNormalized(t *testing.T) {
+	is := New(t)
+
+	sorted := func(o interface{}) interface{} {
+		s := append([]string(nil), o.([]string)...)
+		sort.Strings(s)
+		return s
+	}
+	is.EqualNormalized([]string{"b", "a"}, []string{"a", "b"}, sorted)
+
+	c := NewCollector()
+	c.EqualNormalized([]string{"b", "a"}, []string{"c", "a"}, sorted)
+	is.ErrMsg(c.Err(), "[EqualNormalized] objects of type '[]string' differ at [1]: got \"b\", want \"c\" (after normalization)")
+	is.Equal(c.Failures()[0].Actual, []string{"a", "b"})
+}
+
+func TestEqualSorted(t *testing.T) {
+	is := New(t)
+
+	byValue := func(a, b interface{}) bool { return a.(int) < b.(int) }
+	is.EqualSorted([]int{3, 1, 2}, [3]int{1, 2, 3}, byValue)
+	is.EqualSorted([]int{}, []int(nil), byValue)
+	EqualSortedT(is, []string{"b", "a"}, []string{"a", "b"}, func(a, b string) bool { return a < b })
+	EqualSortedT(is, nil, []string{}, func(a, b string) bool { return a < b })
+
+	c := NewCollector()
+	c.EqualSorted([]int{3, 1}, []int{1, 2}, byValue)
+	c.EqualSorted(1, []int{1}, byValue)
+	EqualSortedT(c.Is, []string{"b", "a"}, []string{"c", "a"}, func(a, b string) bool { return a < b })
+	errs := c.Errs()
+	is.Len(errs, 3)
+	is.ErrMsg(errs[0], "[EqualSorted] objects of type '[]int' differ at [1]: got 3, want 2 (after sorting)")
+	is.ErrMsg(errs[1], "[EqualSorted] expected a slice or an array, got 'int'")
+	is.ErrMsg(errs[2], "[EqualSortedT] objects of type '[]string' differ at [1]: got \"b\", want \"c\" (after sorting)")
+}
@@ -0,0 +1,63 @@
+package is
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CtxDone checks the provided context to determine if it is done.
+func (is *Is) CtxDone(ctx context.Context) bool {
+	is.TB.Helper()
+	select {
+	case <-ctx.Done():
+		return passed(is)
+	default:
+		is.fail("expected context to be done")
+		return false
+	}
+}
+
+// CtxNotDone checks the provided context to determine if it is not done.
+func (is *Is) CtxNotDone(ctx context.Context) bool {
+	is.TB.Helper()
+	select {
+	case <-ctx.Done():
+		is.fail("expected context not to be done, but got: %v", context.Cause(ctx))
+		return false
+	default:
+		return passed(is)
+	}
+}
+
+// CtxErrIs checks the provided context to determine if it is done with an
+// error matching target, such as context.Canceled or
+// context.DeadlineExceeded.
+func (is *Is) CtxErrIs(ctx context.Context, target error) bool {
+	is.TB.Helper()
+	err := ctx.Err()
+	if err == nil {
+		is.fail("expected context error %s, but the context is not done", quoteError(target))
+		return false
+	}
+	if !errors.Is(err, target) {
+		is.fail("expected context error %s, but got %s", quoteError(target), quoteError(err))
+		return false
+	}
+	return passed(is)
+}
+
+// DoneWithin waits up to timeout for the provided context to be done, and
+// fails if it is not.
+func (is *Is) DoneWithin(ctx context.Context, timeout time.Duration) bool {
+	is.TB.Helper()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return passed(is)
+	case <-timer.C:
+		is.fail("expected context to be done within %v", timeout)
+		return false
+	}
+}
@@ -0,0 +1,48 @@
+package is
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	is.CtxNotDone(ctx)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	is.DoneWithin(ctx, time.Second)
+	is.CtxDone(ctx)
+	is.CtxErrIs(ctx, context.Canceled)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.CtxNotDone(ctx)
+	is.Strict().Equal(msg, "expected context not to be done, but got: context canceled")
+	is.CtxErrIs(ctx, context.DeadlineExceeded)
+	is.Strict().Equal(msg, `expected context error "context deadline exceeded", but got "context canceled"`)
+	bg := context.Background()
+	is.CtxDone(bg)
+	is.CtxErrIs(bg, context.Canceled)
+	is.DoneWithin(bg, 10*time.Millisecond)
+	is.CtxErrIs(bg, nil)
+	is.Strict().Equal(msg, "expected context error <nil>, but the context is not done")
+	is.CtxErrIs(ctx, nil)
+	is.Strict().Equal(msg, `expected context error <nil>, but got "context canceled"`)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 7)
+}
@@ -0,0 +1,67 @@
+package is
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// assertionCounter counts the assertions run in a test.
+type assertionCounter struct {
+	n    int64
+	warn bool
+}
+
+// newAssertionCounter returns a counter which reports, when the test of tb
+// finishes, if no assertion was run in it.
+func newAssertionCounter(tb testing.TB, warn bool) *assertionCounter {
+	c := &assertionCounter{warn: warn}
+	if _, ok := tb.(*handlerTB); ok {
+		// There is no end of test outside go test.
+		return c
+	}
+	tb.Cleanup(func() {
+		if atomic.LoadInt64(&c.n) != 0 {
+			return
+		}
+		if c.warn {
+			tb.Log("warning: test finished without running any assertion")
+		} else {
+			tb.Error("test finished without running any assertion")
+		}
+	})
+	return c
+}
+
+// countAssertion counts an assertion run with this Is, if it counts them.
+func countAssertion(is *Is) {
+	if is.counter != nil {
+		atomic.AddInt64(&is.counter.n, 1)
+	}
+}
+
+// ExpectAssertions returns a copy of this Is which counts the assertions
+// run with it, and fails the test if it finishes without running any. This
+// catches tests which silently stopped asserting after a refactor. Copies
+// made with New for subtests count the assertions of each subtest.
+func (is *Is) ExpectAssertions() *Is {
+	newIs := *is
+	newIs.counter = newAssertionCounter(is.TB, false)
+	return &newIs
+}
+
+// WarnNoAssertions is like ExpectAssertions, but it only logs a warning if
+// the test finishes without running any assertion.
+func (is *Is) WarnNoAssertions() *Is {
+	newIs := *is
+	newIs.counter = newAssertionCounter(is.TB, true)
+	return &newIs
+}
+
+// AssertionCount returns the number of assertions run so far in the test
+// of this Is, or 0 if it does not count them, see ExpectAssertions.
+func (is *Is) AssertionCount() int {
+	if is.counter == nil {
+		return 0
+	}
+	return int(atomic.LoadInt64(&is.counter.n))
+}
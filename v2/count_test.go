@@ -0,0 +1,66 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+// cleanupTB is a testing.TB which records errors and logs, and runs its
+// cleanup functions on demand.
+type cleanupTB struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+	logs     []string
+}
+
+func (tb *cleanupTB) Helper()                   {}
+func (tb *cleanupTB) Name() string              { return "TestFake" }
+func (tb *cleanupTB) Cleanup(f func())          { tb.cleanups = append(tb.cleanups, f) }
+func (tb *cleanupTB) Error(args ...interface{}) { tb.errors = append(tb.errors, fmt.Sprint(args...)) }
+func (tb *cleanupTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+func (tb *cleanupTB) Log(args ...interface{}) { tb.logs = append(tb.logs, fmt.Sprint(args...)) }
+
+func (tb *cleanupTB) finish() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+}
+
+func TestExpectAssertions(t *testing.T) {
+	is := New(t)
+
+	tb := &cleanupTB{}
+	is.Equal(New(tb).AssertionCount(), 0)
+	tb.finish()
+	is.Len(tb.errors, 0)
+
+	tb = &cleanupTB{}
+	counted := New(tb).ExpectAssertions()
+	counted.True(true)
+	counted.Lax().Msg("x").Equal(1, 1)
+	is.Equal(counted.AssertionCount(), 2)
+	tb.finish()
+	is.Len(tb.errors, 0)
+
+	tb = &cleanupTB{}
+	New(tb).ExpectAssertions()
+	tb.finish()
+	is.Equal(tb.errors, []string{"test finished without running any assertion"})
+
+	tb = &cleanupTB{}
+	counted = New(tb).WarnNoAssertions()
+	sub := &cleanupTB{}
+	counted.New(sub).True(true)
+	tb.finish()
+	sub.finish()
+	is.Len(tb.errors, 0)
+	is.Equal(tb.logs, []string{"warning: test finished without running any assertion"})
+	is.Len(sub.logs, 0)
+
+	c := NewCollector().ExpectAssertions()
+	c.Equal(1, 2)
+	is.Equal(c.AssertionCount(), 1)
+}
@@ -0,0 +1,18 @@
+// Package is provides assertions for Go tests, built on the testing
+// package.
+//
+// # Argument order
+//
+// Assertions take the actual value first and the expected value second,
+// as in is.Equal(actual, expected), and failure messages label them "got"
+// and "expected" accordingly. The exceptions are EqualType and Kind, whose
+// expected type or kind comes first.
+//
+// The testify-style packages in wrappers/require and wrappers/assert keep
+// testify's expected-first order and swap the arguments when calling this
+// package, so their messages are labeled correctly too. When mixing
+// conventions makes the order unclear at a call site, the labeled form
+// removes the ambiguity:
+//
+//	is.Got(user.Name).Want("bob")
+package is
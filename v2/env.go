@@ -0,0 +1,51 @@
+package is
+
+import (
+	"os"
+)
+
+// EnvSet checks the environment variable key to determine if it is set,
+// possibly to an empty value.
+func (is *Is) EnvSet(key string) bool {
+	is.TB.Helper()
+	if _, ok := os.LookupEnv(key); !ok {
+		is.fail("expected environment variable %s to be set", key)
+		return false
+	}
+	return passed(is)
+}
+
+// EnvEqual checks the environment variable key to determine if it is set to
+// value.
+func (is *Is) EnvEqual(key string, value string) bool {
+	is.TB.Helper()
+	actual, ok := os.LookupEnv(key)
+	if !ok {
+		is.fail("expected environment variable %s to be %q, but it is not set", key, value)
+		return false
+	}
+	if actual != value {
+		is.fail("expected environment variable %s to be %q, but got %q", key, value, actual)
+		return false
+	}
+	return passed(is)
+}
+
+// WithEnv calls fn with the environment variable key set to value, and
+// restores its previous state when fn returns, even if it panics. It uses
+// TB.Setenv, so it can not be used in parallel tests, and the variable is
+// restored when the test ends even if the program is interrupted while fn
+// is running.
+func (is *Is) WithEnv(key string, value string, fn func()) {
+	is.TB.Helper()
+	prev, had := os.LookupEnv(key)
+	is.TB.Setenv(key, value)
+	defer func() {
+		if had {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,40 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestEnv(t *testing.T) {
+	is := New(t)
+
+	const key = "IS_TEST_ENV"
+	is.WithEnv(key, "a", func() {
+		is.EnvSet(key)
+		is.EnvEqual(key, "a")
+		is.WithEnv(key, "", func() {
+			is.EnvSet(key)
+			is.EnvEqual(key, "")
+		})
+		is.EnvEqual(key, "a")
+	})
+	_, ok := os.LookupEnv(key)
+	is.False(ok)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.EnvSet(key)
+	is.EnvEqual(key, "a")
+	is.WithEnv(key, "b", func() {
+		is.EnvEqual(key, "a")
+	})
+
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, 3)
+	is.Equal(msg, `expected environment variable IS_TEST_ENV to be "a", but got "b"`)
+}
@@ -0,0 +1,198 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// flattenErrors returns the leaf errors of an error tree built with
+// errors.Join or any other error implementing Unwrap() []error. An error
+// that is not a multi-error is returned as the only element.
+func flattenErrors(e error) []error {
+	if isNil(e) {
+		return nil
+	}
+	multi, ok := e.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{e}
+	}
+	var errs []error
+	for _, sub := range multi.Unwrap() {
+		errs = append(errs, flattenErrors(sub)...)
+	}
+	return errs
+}
+
+// formatErrors formats a list of errors one per line for failure messages.
+func formatErrors(errs []error) string {
+	if len(errs) == 0 {
+		return "[]"
+	}
+	var b strings.Builder
+	for i, e := range errs {
+		fmt.Fprintf(&b, "\n\t[%d] %v", i, e)
+	}
+	return b.String()
+}
+
+// ErrContains checks the provided error object to determine if an error is
+// present and its message contains substr.
+func (is *Is) ErrContains(e error, substr string) bool {
+	is.TB.Helper()
+	if isNil(e) {
+		is.fail("expected error containing %q", substr)
+		return false
+	}
+	if !strings.Contains(e.Error(), substr) {
+		is.fail("expected error message %q to contain %q", e.Error(), substr)
+		return false
+	}
+	return passed(is)
+}
+
+// ErrMatches checks the provided error object to determine if an error is
+// present and its message matches the regular expression pattern.
+func (is *Is) ErrMatches(e error, pattern string) bool {
+	is.TB.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		is.fail("invalid pattern %q: %v", pattern, err)
+		return false
+	}
+	if isNil(e) {
+		is.fail("expected error matching %q", pattern)
+		return false
+	}
+	if !re.MatchString(e.Error()) {
+		is.fail("expected error message %q to match %q", e.Error(), pattern)
+		return false
+	}
+	return passed(is)
+}
+
+// ErrMsgOneOf checks the provided error object to determine if an error is
+// present and its message is one of the candidates. This is useful for
+// errors whose text varies by platform or Go version.
+func (is *Is) ErrMsgOneOf(e error, candidates ...string) bool {
+	is.TB.Helper()
+	if isNil(e) {
+		is.fail("expected error with one of the messages %q", candidates)
+		return false
+	}
+	for _, c := range candidates {
+		if e.Error() == c {
+			return passed(is)
+		}
+	}
+	is.fail("expected error message %q to be one of %q", e.Error(), candidates)
+	return false
+}
+
+// ErrMsgHasPrefix checks the provided error object to determine if an error
+// is present and its message begins with prefix.
+func (is *Is) ErrMsgHasPrefix(e error, prefix string) bool {
+	is.TB.Helper()
+	if isNil(e) {
+		is.fail("expected error with prefix %q", prefix)
+		return false
+	}
+	if !strings.HasPrefix(e.Error(), prefix) {
+		is.fail("expected error message %q to have prefix %q", e.Error(), prefix)
+		return false
+	}
+	return passed(is)
+}
+
+// ErrCount checks the provided error object to determine if it contains n
+// errors. Errors created with errors.Join, or implementing Unwrap() []error,
+// are flattened recursively. A nil error contains 0 errors and any other
+// error contains 1.
+func (is *Is) ErrCount(e error, n int) bool {
+	is.TB.Helper()
+	errs := flattenErrors(e)
+	if len(errs) != n {
+		is.fail("expected %d errors, but got %d: %s", n, len(errs), formatErrors(errs))
+		return false
+	}
+	return passed(is)
+}
+
+// ErrsContain checks the provided error object to determine if target is
+// found in its tree, as reported by errors.Is. Unlike a bare errors.Is check,
+// the failure message lists all the flattened errors.
+func (is *Is) ErrsContain(e error, target error) bool {
+	is.TB.Helper()
+	if !errors.Is(e, target) {
+		is.fail("expected error %s to be among: %s", quoteError(target), formatErrors(flattenErrors(e)))
+		return false
+	}
+	return passed(is)
+}
+
+// ErrIs checks the provided error object to determine if any error in its
+// chain matches target, as reported by errors.Is.
+func (is *Is) ErrIs(e error, target error) bool {
+	is.TB.Helper()
+	if isNil(e) && !isNil(target) {
+		is.fail("expected error %q, but got no error", target.Error())
+		return false
+	}
+	if !errors.Is(e, target) {
+		is.fail("expected error %s to be %s", quoteError(e), quoteError(target))
+		return false
+	}
+	return passed(is)
+}
+
+// NotErrIs checks the provided error object to determine if no error in its
+// chain matches target, as reported by errors.Is. This is useful to verify
+// that internal sentinel errors do not leak to callers.
+func (is *Is) NotErrIs(e error, target error) bool {
+	is.TB.Helper()
+	if errors.Is(e, target) {
+		is.fail("expected error %s not to be %s", quoteError(e), quoteError(target))
+		return false
+	}
+	return passed(is)
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ErrAs checks the provided error object to determine if any error in its
+// chain can be assigned to target, as reported by errors.As. target must be
+// a non-nil pointer to an interface or to a type implementing error, and is
+// set to the matching error.
+func (is *Is) ErrAs(e error, target interface{}) bool {
+	is.TB.Helper()
+	t := reflect.TypeOf(target)
+	if target == nil || t.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() {
+		is.fail("expected target '%s' to be a non-nil pointer", objectTypeName(target))
+		return false
+	}
+	if t.Elem().Kind() != reflect.Interface && !t.Elem().Implements(errorType) {
+		is.fail("expected target '%s' to point to an interface or a type implementing error", objectTypeName(target))
+		return false
+	}
+	if isNil(e) {
+		is.fail("expected error assignable to '%s', but got no error", t.Elem())
+		return false
+	}
+	if !errors.As(e, target) {
+		is.fail("expected error %q to be assignable to '%s'", e.Error(), t.Elem())
+		return false
+	}
+	return passed(is)
+}
+
+// quoteError returns the quoted message of the error e, or "<nil>" if e is
+// nil.
+func quoteError(e error) string {
+	if isNil(e) {
+		return "<nil>"
+	}
+	return strconv.Quote(e.Error())
+}
@@ -0,0 +1,130 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrContains(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	err := errors.New("open config.yaml: permission denied")
+	is.ErrContains(err, "permission denied")
+	is.ErrMatches(err, `^open \S+\.yaml: `)
+	is.ErrMsgOneOf(err, "open config.yaml: access denied", "open config.yaml: permission denied")
+	is.ErrMsgHasPrefix(err, "open config.yaml: ")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.ErrContains(nil, "denied")
+	is.ErrContains(err, "not found")
+	is.ErrMatches(nil, "denied")
+	is.ErrMatches(err, "^permission")
+	is.ErrMatches(err, "(")
+	is.ErrMsgOneOf(nil, "a")
+	is.ErrMsgOneOf(err, "a", "b")
+	is.Equal(msg, `expected error message "open config.yaml: permission denied" to be one of ["a" "b"]`)
+	is.ErrMsgHasPrefix(nil, "open")
+	is.ErrMsgHasPrefix(err, "read")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 9)
+}
+
+func TestErrJoined(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	errA := errors.New("a")
+	errB := errors.New("b")
+	errC := errors.New("c")
+	joined := errors.Join(errA, fmt.Errorf("wrapped: %w", errors.Join(errB, errC)))
+	multi := fmt.Errorf("%w and %w", errA, errB)
+	is.ErrCount(nil, 0)
+	is.ErrCount(errA, 1)
+	is.ErrCount(errors.Join(errA, errors.Join(errB, errC)), 3)
+	is.ErrCount(multi, 2)
+	is.ErrsContain(joined, errC)
+	is.ErrsContain(multi, errB)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.ErrCount(errors.Join(errA, errB), 1)
+	is.Equal(msg, "expected 1 errors, but got 2: \n\t[0] a\n\t[1] b")
+	is.ErrsContain(errors.Join(errA, errB), errC)
+	is.ErrsContain(nil, errC)
+	is.ErrsContain(errA, nil)
+	is.Equal(msg, "expected error <nil> to be among: \n\t[0] a")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 4)
+}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func TestErrIsAs(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	errA := errors.New("a")
+	wrapped := fmt.Errorf("wrapped: %w", &codeError{code: 7})
+	is.ErrIs(fmt.Errorf("wrapped: %w", errA), errA)
+	is.NotErrIs(fmt.Errorf("wrapped: %v", errA), errA)
+	is.NotErrIs(nil, errA)
+	var ce *codeError
+	is.ErrAs(wrapped, &ce)
+	is.Equal(ce.code, 7)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.ErrIs(errors.New("b"), errA)
+	is.Equal(msg, `expected error "b" to be "a"`)
+	is.ErrAs(errA, &ce)
+	is.Equal(msg, `expected error "a" to be assignable to '*is.codeError'`)
+	is.ErrAs(wrapped, ce)
+	is.ErrAs(wrapped, nil)
+	var n int
+	is.ErrAs(wrapped, &n)
+	is.NotErrIs(fmt.Errorf("wrapped: %w", errA), errA)
+	is.Equal(msg, `expected error "wrapped: a" not to be "a"`)
+	is.ErrIs(nil, errA)
+	is.Equal(msg, `expected error "a", but got no error`)
+	is.ErrIs(errA, nil)
+	is.Equal(msg, `expected error "a" to be <nil>`)
+	is.ErrAs(nil, &ce)
+	is.Equal(msg, `expected error assignable to '*is.codeError', but got no error`)
+	is.NotErrIs(nil, nil)
+	is.Equal(msg, `expected error <nil> not to be <nil>`)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 10)
+}
@@ -0,0 +1,39 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// exitEnv is the environment variable identifying the call to ShouldExit
+// whose function a subprocess runs.
+const exitEnv = "IS_SHOULD_EXIT"
+
+// ShouldExit runs fn in a subprocess and checks that it exits with the
+// provided code, for testing code which calls os.Exit, such as main
+// functions. fn exits with code 0 if it returns. The subprocess re-executes
+// the test binary, running only the current test up to this call, so fn
+// must not depend on state set outside of the test. The returned CmdTester
+// has assertions on the output of fn:
+//
+//	is.ShouldExit(2, func() { run([]string{"--bad-flag"}) }).
+//		StderrContains("usage:")
+func (is *Is) ShouldExit(code int, fn func()) *CmdTester {
+	is.TB.Helper()
+	_, file, line, _ := runtime.Caller(1)
+	key := fmt.Sprintf("%s:%s:%d", is.TB.Name(), file, line)
+	if os.Getenv(exitEnv) == key {
+		fn()
+		os.Exit(0)
+	}
+	parts := strings.Split(is.TB.Name(), "/")
+	for i, part := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(part) + "$"
+	}
+	c := is.Cmd(os.Args[0], "-test.run="+strings.Join(parts, "/")).
+		WithEnv(exitEnv + "=" + key)
+	return c.ExitCode(code)
+}
@@ -0,0 +1,35 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestShouldExit(t *testing.T) {
+	is := New(t)
+
+	is.ShouldExit(3, func() {
+		fmt.Print("bye")
+		fmt.Fprint(os.Stderr, "oops")
+		os.Exit(3)
+	}).StdoutContains("bye").StderrContains("oops")
+	is.ShouldExit(0, func() {})
+
+	t.Run("sub test", func(t *testing.T) {
+		is := is.New(t)
+		is.ShouldExit(4, func() { os.Exit(4) })
+	})
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.ShouldExit(1, func() { os.Exit(2) })
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 1)
+	is.True(strings.HasPrefix(msg, "expected exit code 1, but got 2 (exit status 2)"))
+}
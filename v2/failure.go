@@ -0,0 +1,76 @@
+package is
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Failure is a failed assertion, for tools and custom reporters which need
+// structured access to what failed rather than the formatted message.
+type Failure struct {
+	// Assertion is the name of the failed assertion, such as "Equal".
+	Assertion string
+	// Message is the failure message, as reported to the testing object.
+	Message string
+	// Caller is the file and line of the failed assertion in the test.
+	Caller string
+	// Actual and Expected are the values compared by the assertion, when
+	// it compares values.
+	Actual   interface{}
+	Expected interface{}
+	// Diff describes where nested values differ, when they do.
+	Diff string
+	// Warning is true for failures of an Is returned by Warn, which are
+	// logged without failing the test.
+	Warning bool
+}
+
+// Error returns the failure message, so that a Failure can be returned as
+// an error.
+func (f Failure) Error() string {
+	return f.Message
+}
+
+// failureList holds the failures of an Is and of the copies made from it.
+type failureList struct {
+	mu       sync.Mutex
+	failures []Failure
+}
+
+// newFailure returns the Failure of the assertion being run.
+func newFailure(is *Is, msg string) Failure {
+	f := Failure{Message: msg, Warning: is.warn}
+	name, frame := assertionFrame()
+	f.Assertion = name
+	if frame.File != "" {
+		f.Caller = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+	}
+	if is.values != nil {
+		f.Actual = is.values.actual
+		f.Expected = is.values.expected
+		f.Diff = is.values.diff
+	}
+	return f
+}
+
+// addFailure records f in the failures of this Is.
+func (is *Is) addFailure(f Failure) {
+	if is.failures == nil {
+		return
+	}
+	is.failures.mu.Lock()
+	defer is.failures.mu.Unlock()
+	is.failures.failures = append(is.failures.failures, f)
+}
+
+// Failures returns the failures reported so far by this Is and by the
+// copies made from it, such as with Lax or Msg. This is mostly useful in
+// Lax mode, where the test goes on after a failure.
+func (is *Is) Failures() []Failure {
+	if is.failures == nil {
+		return nil
+	}
+	is.failures.mu.Lock()
+	defer is.failures.mu.Unlock()
+	return append([]Failure(nil), is.failures.failures...)
+}
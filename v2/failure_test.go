@@ -0,0 +1,42 @@
+package is
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFailures(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	is.Len(c.Failures(), 0)
+	c.Equal(1, 1)
+	c.Equal([]int{1}, []int{2})
+	c.Msg("name").NotEmpty("")
+
+	failures := c.Failures()
+	is.Len(failures, 2)
+	is.Matches(failures[0].Caller, `/failure_test\.go:\d+$`)
+	failures[0].Caller = ""
+	failures[1].Caller = ""
+	is.Equal(failures, []Failure{
+		{
+			Assertion: "Equal",
+			Message:   "[Equal] objects of type '[]int' differ at [0]: got 1, want 2",
+			Actual:    []int{1},
+			Expected:  []int{2},
+			Diff:      "[0]: got 1, want 2",
+		},
+		{
+			Assertion: "NotEmpty",
+			Message:   "[NotEmpty] expected object 'string' not to be empty - name",
+		},
+	})
+
+	var err error = failures[1]
+	var f Failure
+	is.True(errors.As(err, &f))
+	is.Equal(err.Error(), "[NotEmpty] expected object 'string' not to be empty - name")
+
+	is.Len((&Is{TB: t}).Failures(), 0)
+}
@@ -0,0 +1,266 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseFieldPath splits a path such as "User.Tags[0]" into its segments,
+// which are names and indices in brackets.
+func parseFieldPath(path string) ([]string, error) {
+	var segs []string
+	for _, part := range strings.Split(path, ".") {
+		name := part
+		var indices []string
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			name = part[:i]
+			rest := part[i:]
+			for rest != "" {
+				end := strings.IndexByte(rest, ']')
+				if rest[0] != '[' || end < 0 {
+					return nil, fmt.Errorf("invalid path %q", path)
+				}
+				indices = append(indices, rest[:end+1])
+				rest = rest[end+1:]
+			}
+		}
+		if name == "" && (len(segs) > 0 || len(indices) == 0) {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+		if name != "" {
+			segs = append(segs, name)
+		}
+		segs = append(segs, indices...)
+	}
+	return segs, nil
+}
+
+// lookupFieldPath returns the value at path in o: names select struct
+// fields or string map keys, and indices in brackets select elements of
+// slices and arrays, or map keys. Pointers and interfaces are followed.
+func lookupFieldPath(o interface{}, path string) (interface{}, error) {
+	segs, err := parseFieldPath(path)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(o)
+	if v.IsValid() {
+		// Make unexported struct fields readable.
+		addressable := reflect.New(v.Type()).Elem()
+		addressable.Set(v)
+		v = addressable
+	}
+	done := ""
+	for _, seg := range segs {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Errorf("%s is nil", pathOrRoot(done))
+			}
+			v = v.Elem()
+		}
+		if v, err = lookupSegment(v, seg); err != nil {
+			return nil, fmt.Errorf("%s: %v", pathOrRoot(done), err)
+		}
+		if seg[0] == '[' || done == "" {
+			done += seg
+		} else {
+			done += "." + seg
+		}
+	}
+	x, ok := valueInterface(v)
+	if !ok {
+		return nil, fmt.Errorf("cannot read %s", path)
+	}
+	return x, nil
+}
+
+func lookupSegment(v reflect.Value, seg string) (reflect.Value, error) {
+	key := seg
+	indexed := seg[0] == '['
+	if indexed {
+		key = seg[1 : len(seg)-1]
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if !indexed {
+			if f := v.FieldByName(key); f.IsValid() {
+				return f, nil
+			}
+		}
+		return reflect.Value{}, fmt.Errorf("no field %s in %s", key, v.Type())
+	case reflect.Map:
+		k := reflect.ValueOf(key)
+		if v.Type().Key().Kind() != reflect.String {
+			var err error
+			if k, err = parseMapKey(key, v.Type().Key()); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+		e := v.MapIndex(k.Convert(v.Type().Key()))
+		if !e.IsValid() {
+			return reflect.Value{}, fmt.Errorf("no key %s in %s", key, v.Type())
+		}
+		return e, nil
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if !indexed || err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid index %s for %s", seg, v.Type())
+		}
+		if i < 0 || i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("index %d out of range for length %d", i, v.Len())
+		}
+		return v.Index(i), nil
+	}
+	if !v.IsValid() {
+		return reflect.Value{}, fmt.Errorf("no %s in nil", seg)
+	}
+	return reflect.Value{}, fmt.Errorf("no %s in %s", seg, v.Type())
+}
+
+// parseMapKey parses a map key of a numeric or boolean type.
+func parseMapKey(s string, t reflect.Type) (reflect.Value, error) {
+	k := reflect.New(t).Elem()
+	var err error
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, t.Bits()); err == nil {
+			k.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			k.SetUint(n)
+		}
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			k.SetBool(b)
+		}
+	default:
+		return k, fmt.Errorf("unsupported key type %s", t)
+	}
+	if err != nil {
+		return k, fmt.Errorf("invalid key %s for %s", s, t)
+	}
+	return k, nil
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// MatchFields checks that the values at the paths of the provided object,
+// which are the keys of expected, are equal to the values of expected.
+// Other fields are ignored. Paths are names of struct fields or string map
+// keys separated with dots, and indices in brackets, such as
+// "User.Tags[0]". All the mismatches are reported.
+//
+//	is.MatchFields(resp, map[string]interface{}{
+//		"Status":       200,
+//		"User.Name":    "bob",
+//		"User.Tags[0]": "admin",
+//	})
+func (is *Is) MatchFields(actual interface{}, expected map[string]interface{}) bool {
+	is.TB.Helper()
+	paths := make([]string, 0, len(expected))
+	for path := range expected {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var b strings.Builder
+	for _, path := range paths {
+		got, err := lookupFieldPath(actual, path)
+		if err != nil {
+			fmt.Fprintf(&b, "\n\t%s: %v", path, err)
+			continue
+		}
+		want := expected[path]
+		if equal, c := compareObjects(got, want, is.equalOpts, is.formatOpts); !equal {
+			if c.nested() {
+				fmt.Fprintf(&b, "\n\t%s: differs at %s", path, strings.ReplaceAll(c.mismatch(), "\n", "\n\t"))
+			} else {
+				fmt.Fprintf(&b, "\n\t%s: got %s, want %s", path, is.formatValue(got), is.formatValue(want))
+			}
+		}
+	}
+	if b.Len() > 0 {
+		is.fail("expected fields of '%s' to match, but:%s", objectTypeName(actual), b.String())
+		return false
+	}
+	return passed(is)
+}
+
+// FieldValue is a value at a path in an object, to make assertions about.
+// It is created with Is.Field.
+type FieldValue struct {
+	is   *Is
+	obj  interface{}
+	path string
+}
+
+// Field returns the value at path in the provided object, with the path
+// syntax of MatchFields, to make assertions about it. Failures are prefixed
+// with the path, see Named:
+//
+//	is.Field(resp, "User.Address.City").Equal("Oslo")
+//	is.Field(resp, "User.Tags[0]").NotEqual("")
+func (is *Is) Field(obj interface{}, path string) *FieldValue {
+	return &FieldValue{is: is.Named(path), obj: obj, path: path}
+}
+
+// value returns the value at the path, or fails if it cannot be found.
+func (f *FieldValue) value() (interface{}, bool) {
+	f.is.TB.Helper()
+	v, err := lookupFieldPath(f.obj, f.path)
+	if err != nil {
+		f.is.fail("cannot find field in '%s': %v", objectTypeName(f.obj), err)
+		return nil, false
+	}
+	return v, true
+}
+
+// Value returns the value at the path, or nil if it cannot be found, in
+// which case the assertion fails.
+func (f *FieldValue) Value() interface{} {
+	f.is.TB.Helper()
+	v, _ := f.value()
+	return v
+}
+
+// Equal checks the value at the path to determine if it is equal to
+// expected, like Is.Equal.
+func (f *FieldValue) Equal(expected interface{}) bool {
+	f.is.TB.Helper()
+	v, ok := f.value()
+	return ok && f.is.Equal(v, expected)
+}
+
+// NotEqual checks the value at the path to determine if it is not equal to
+// unexpected, like Is.NotEqual.
+func (f *FieldValue) NotEqual(unexpected interface{}) bool {
+	f.is.TB.Helper()
+	v, ok := f.value()
+	return ok && f.is.NotEqual(v, unexpected)
+}
+
+// Nil checks the value at the path to determine if it is nil, like Is.Nil.
+func (f *FieldValue) Nil() bool {
+	f.is.TB.Helper()
+	v, ok := f.value()
+	return ok && f.is.Nil(v)
+}
+
+// NotNil checks the value at the path to determine if it is not nil, like
+// Is.NotNil.
+func (f *FieldValue) NotNil() bool {
+	f.is.TB.Helper()
+	v, ok := f.value()
+	return ok && f.is.NotNil(v)
+}
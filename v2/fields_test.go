@@ -0,0 +1,104 @@
+package is
+
+import (
+	"testing"
+)
+
+type fieldsAddress struct {
+	City string
+	zip  string
+}
+
+type fieldsUser struct {
+	Name    string
+	Tags    []string
+	Address *fieldsAddress
+	Scores  map[string]int
+	ByID    map[int]string
+	Extra   interface{}
+}
+
+func TestMatchFields(t *testing.T) {
+	is := New(t)
+
+	u := fieldsUser{
+		Name:    "bob",
+		Tags:    []string{"admin", "ops"},
+		Address: &fieldsAddress{City: "Oslo", zip: "0150"},
+		Scores:  map[string]int{"go": 9},
+		ByID:    map[int]string{7: "seven"},
+		Extra:   []int{1, 2},
+	}
+	is.MatchFields(u, map[string]interface{}{
+		"Name":         "bob",
+		"Tags[1]":      "ops",
+		"Address.City": "Oslo",
+		"Address.zip":  "0150",
+		"Scores.go":    9,
+		"Scores[go]":   9,
+		"ByID[7]":      "seven",
+		"Extra[1]":     2,
+		"Tags":         []string{"admin", "ops"},
+	})
+	is.MatchFields(&u, map[string]interface{}{"Name": "bob"})
+
+	c := NewCollector()
+	c.MatchFields(u, map[string]interface{}{
+		"Name":          "alice",
+		"Tags":          []string{"admin", "dev"},
+		"Tags[5]":       "x",
+		"Address.Zip":   "",
+		"ByID[x]":       "",
+		"Scores.rust":   1,
+		"Name.First":    "",
+		"Tags..":        "",
+		"Address.City":  "Oslo",
+		"Address[City]": "Oslo",
+		"Address":       &fieldsAddress{City: "Bergen", zip: "0150"},
+	})
+	is.ErrMsg(c.Err(), `[MatchFields] expected fields of 'is.fieldsUser' to match, but:
+	Address: differs at fieldsAddress.City: got "Oslo", want "Bergen"
+	Address.Zip: Address: no field Zip in is.fieldsAddress
+	Address[City]: Address: no field City in is.fieldsAddress
+	ByID[x]: ByID: invalid key x for int
+	Name: got bob, want alice
+	Name.First: Name: no First in string
+	Scores.rust: Scores: no key rust in map[string]int
+	Tags: differs at [1]: got "ops", want "dev"
+	Tags..: invalid path "Tags.."
+	Tags[5]: Tags: index 5 out of range for length 2`)
+
+	c = NewCollector()
+	c.MatchFields(fieldsUser{}, map[string]interface{}{"Address.City": ""})
+	c.MatchFields(nil, map[string]interface{}{"Name": ""})
+	is.ErrMsg(c.Err(), "[MatchFields] expected fields of 'is.fieldsUser' to match, but:\n\tAddress.City: Address is nil\n"+
+		"[MatchFields] expected fields of '<nil>' to match, but:\n\tName: (root): no Name in nil")
+}
+
+func TestField(t *testing.T) {
+	is := New(t)
+
+	u := &fieldsUser{
+		Tags:    []string{"admin"},
+		Address: &fieldsAddress{City: "Oslo"},
+		ByID:    map[int]string{7: "seven"},
+	}
+	is.Field(u, "Address.City").Equal("Oslo")
+	is.Field(u, "Tags[0]").NotEqual("")
+	is.Field(u, "ByID[7]").Equal("seven")
+	is.Field(u, "Extra").Nil()
+	is.Field(u, "Address").NotNil()
+	is.Equal(is.Field(u, "Tags").Value(), []string{"admin"})
+
+	c := NewCollector()
+	c.Field(u, "Address.City").Equal("Bergen")
+	c.Field(u, "Tags[1]").Equal("ops")
+	c.Named("user").Field(u, "Extra").NotNil()
+	is.Nil(c.Field(u, "Name.First").Value())
+	errs := c.Errs()
+	is.Len(errs, 4)
+	is.ErrMsg(errs[0], "[Equal] Address.City: got 'Oslo' (string). expected 'Bergen' (string)")
+	is.ErrMsg(errs[1], "[Equal] Tags[1]: cannot find field in '*is.fieldsUser': Tags: index 1 out of range for length 1")
+	is.ErrMsg(errs[2], "[NotNil] user/Extra: expected object '<nil>' not to be nil")
+	is.ErrMsg(errs[3], "[Value] Name.First: cannot find field in '*is.fieldsUser': Name: no First in string")
+}
@@ -0,0 +1,108 @@
+package is
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// FileExists checks the provided path to determine if it exists and is not
+// a directory.
+func (is *Is) FileExists(path string) bool {
+	is.TB.Helper()
+	info, err := os.Lstat(path)
+	if err != nil {
+		is.fail("expected file %q to exist: %v", path, err)
+		return false
+	}
+	if info.IsDir() {
+		is.fail("expected %q to be a file, but it is a directory", path)
+		return false
+	}
+	return passed(is)
+}
+
+// writeTempFile writes data to a new file named after pattern, like with
+// os.CreateTemp, in the temporary directory of the test, and returns its
+// path.
+func (is *Is) writeTempFile(pattern string, data []byte) string {
+	is.TB.Helper()
+	f, err := os.CreateTemp(is.TB.TempDir(), pattern)
+	if err != nil {
+		is.fail("failed to create fixture file: %v", err)
+		return ""
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		is.fail("failed to write fixture file %q: %v", f.Name(), err)
+		return ""
+	}
+	return f.Name()
+}
+
+// TempFile writes content to a new file in the temporary directory of the
+// test, see testing.T.TempDir, and returns its path. It fails if the file
+// cannot be written.
+func (is *Is) TempFile(content []byte) string {
+	is.TB.Helper()
+	return is.writeTempFile("fixture-*", content)
+}
+
+// TempJSON writes the JSON encoding of v to a new file in the temporary
+// directory of the test, and returns its path. It fails if v cannot be
+// encoded or the file cannot be written.
+func (is *Is) TempJSON(v interface{}) string {
+	is.TB.Helper()
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		is.fail("failed to encode fixture as JSON: %v", err)
+		return ""
+	}
+	return is.writeTempFile("fixture-*.json", append(data, '\n'))
+}
+
+// testdataPath returns path inside the testdata directory of the package
+// under test, unless it is absolute.
+func testdataPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join("testdata", path)
+}
+
+// MustReadFile returns the content of the fixture file at path, relative to
+// the testdata directory of the package unless it is absolute. It fails
+// with the path and the underlying error if the file cannot be read, and
+// returns nil.
+func (is *Is) MustReadFile(path string) []byte {
+	is.TB.Helper()
+	path = testdataPath(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		is.fail("failed to read fixture file %q: %v", path, err)
+		return nil
+	}
+	return data
+}
+
+// MustReadJSON decodes the JSON fixture file at path, relative to the
+// testdata directory of the package unless it is absolute, into a T. It
+// fails with the path and the underlying error if the file cannot be read
+// or decoded, and returns the zero value of T.
+func MustReadJSON[T any](is *Is, path string) T {
+	is.TB.Helper()
+	var v T
+	data := is.MustReadFile(path)
+	if data == nil {
+		return v
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		var zero T
+		is.fail("failed to decode fixture file %q as '%s': %v", testdataPath(path), typeName[T](), err)
+		return zero
+	}
+	return v
+}
@@ -0,0 +1,82 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileExists(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	is.FileExists(path)
+	is.FileExists(dir)
+	is.FileExists(filepath.Join(dir, "missing"))
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+}
+
+func TestTempFile(t *testing.T) {
+	is := New(t)
+
+	path := is.TempFile([]byte("hello"))
+	data, err := os.ReadFile(path)
+	is.NotErr(err)
+	is.Equal(string(data), "hello")
+	is.NotEqual(is.TempFile(nil), path)
+
+	path = is.TempJSON(map[string]int{"a": 1})
+	is.Equal(filepath.Ext(path), ".json")
+	data, err = os.ReadFile(path)
+	is.NotErr(err)
+	is.JSONEq(string(data), `{"a": 1}`)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Equal(is.TempJSON(func() {}), "")
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 1)
+	is.HasPrefix(msg, "failed to encode fixture as JSON: ")
+}
+
+func TestMustReadFile(t *testing.T) {
+	is := New(t)
+
+	is.Equal(string(is.MustReadFile("invalid.json")), "not json\n")
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	is.Equal(MustReadJSON[user](is, "user.json"), user{Name: "bob", Age: 7})
+	abs, err := filepath.Abs(filepath.Join("testdata", "user.json"))
+	is.NotErr(err)
+	is.Equal(MustReadJSON[map[string]interface{}](is, abs)["name"], "bob")
+
+	hit := 0
+	var msgs []string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	})
+	is.Nil(is.MustReadFile("missing.txt"))
+	is.Equal(MustReadJSON[user](is, "invalid.json"), user{})
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+	is.HasPrefix(msgs[0], `failed to read fixture file "testdata/missing.txt": `)
+	is.HasPrefix(msgs[1], `failed to decode fixture file "testdata/invalid.json" as 'is.user': `)
+}
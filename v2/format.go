@@ -0,0 +1,344 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatValue renders the provided object for failure messages. The output
+// is similar to the %+v verb of fmt: Error and String methods are used when
+// available, structs include field names and map keys are sorted. Values of
+// struct fields tagged with `is:"redact"` are hidden, and values of types
+// registered with RegisterRenderer use the registered function.
+//
+// Unlike fmt, pointers nested in other values are followed instead of being
+// printed as addresses, and values referencing themselves are rendered with
+// a "<cycle to PATH>" marker instead of recursing forever.
+func formatValue(o interface{}) string {
+	return formatOptions{}.format(o)
+}
+
+// formatOptions limits the size of values rendered for failure messages.
+// Zero values mean no limit.
+type formatOptions struct {
+	maxDepth int
+	maxWidth int
+	maxDiffs int
+	noDiff   bool
+}
+
+// defaultMaxDiffs is the number of differences between slices listed in
+// failure messages, unless set with MaxDiffs.
+const defaultMaxDiffs = 10
+
+func (opts formatOptions) diffLimit() int {
+	if opts.maxDiffs > 0 {
+		return opts.maxDiffs
+	}
+	return defaultMaxDiffs
+}
+
+// FormatLimits returns a copy of this instance of Is which limits how much
+// of large values is printed in failure messages. Containers nested deeper
+// than depth are printed as "...", and only the first width elements of
+// slices, arrays and maps, and fields of structs, are printed. Map keys are
+// always printed in sorted order, so output is reproducible. A limit of 0
+// means no limit, which is the default.
+func (is *Is) FormatLimits(depth int, width int) *Is {
+	newIs := *is
+	newIs.formatOpts.maxDepth = depth
+	newIs.formatOpts.maxWidth = width
+	return &newIs
+}
+
+// MaxDiffs returns a copy of this instance of Is which lists at most n
+// differences in failure messages when elements of compared slices or arrays
+// differ in several places. The total number of differences is always
+// reported. The default is 10.
+func (is *Is) MaxDiffs(n int) *Is {
+	newIs := *is
+	newIs.formatOpts.maxDiffs = n
+	return &newIs
+}
+
+// NoDiff returns a copy of this instance of Is whose failure messages for
+// values found different, by Equal and the like, only give the types and
+// lengths of the values, and the path of the first difference in nested
+// values. The values themselves are not printed, nor kept in Failure or in
+// failure reports. Use this for huge values, such as multi-megabyte
+// payloads, to keep test logs small.
+func (is *Is) NoDiff() *Is {
+	newIs := *is
+	newIs.formatOpts.noDiff = true
+	return &newIs
+}
+
+// formatValue renders the provided object like the formatValue function,
+// applying the limits set with FormatLimits.
+func (is *Is) formatValue(o interface{}) string {
+	return is.formatOpts.format(o)
+}
+
+// format renders the provided object like formatValue, with these limits.
+func (opts formatOptions) format(o interface{}) string {
+	var v reflect.Value
+	switch x := o.(type) {
+	case reflect.Value:
+		v = x
+	default:
+		v = reflect.ValueOf(o)
+	}
+	r := &renderer{opts: opts, visiting: map[visitKey]string{}}
+	if v.IsValid() {
+		r.root = rootName(v.Type())
+		if !v.CanAddr() && v.CanInterface() {
+			// Make unexported struct fields addressable, for the
+			// functions registered with RegisterRenderer.
+			addressable := reflect.New(v.Type()).Elem()
+			addressable.Set(v)
+			v = addressable
+		}
+	}
+	r.render(v)
+	return r.b.String()
+}
+
+// redacted replaces the values of struct fields tagged with `is:"redact"`.
+const redacted = "<redacted>"
+
+// visitKey identifies a pointer, map or slice being rendered.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// renderer holds the state of formatValue. visiting maps the values being
+// rendered, which are the ancestors of the current value, to their path.
+type renderer struct {
+	opts     formatOptions
+	depth    int
+	b        strings.Builder
+	visiting map[visitKey]string
+	root     string
+	path     []string
+}
+
+func (r *renderer) pathString() string {
+	p := r.root + strings.Join(r.path, "")
+	if r.root == "" {
+		p = strings.TrimPrefix(p, ".")
+	}
+	if p == "" {
+		return "(root)"
+	}
+	return p
+}
+
+// enter marks v as being rendered, or writes a cycle marker and returns
+// false if it already is.
+func (r *renderer) enter(key visitKey) bool {
+	if path, ok := r.visiting[key]; ok {
+		fmt.Fprintf(&r.b, "<cycle to %s>", path)
+		return false
+	}
+	r.visiting[key] = r.pathString()
+	return true
+}
+
+func (r *renderer) renderElem(seg string, v reflect.Value) {
+	r.path = append(r.path, seg)
+	r.depth++
+	r.render(v)
+	r.depth--
+	r.path = r.path[:len(r.path)-1]
+}
+
+// tooDeep reports whether containers at the current depth are elided.
+func (r *renderer) tooDeep() bool {
+	return r.opts.maxDepth > 0 && r.depth >= r.opts.maxDepth
+}
+
+// truncated reports whether the i-th element of a container of n elements
+// is beyond the width limit, in which case the number of elided elements
+// is written.
+func (r *renderer) truncated(i int, n int) bool {
+	if r.opts.maxWidth <= 0 || i < r.opts.maxWidth {
+		return false
+	}
+	fmt.Fprintf(&r.b, "... (%d more)", n-i)
+	return true
+}
+
+func (r *renderer) render(v reflect.Value) {
+	if !v.IsValid() {
+		r.b.WriteString("<nil>")
+		return
+	}
+
+	if s, ok := registeredRender(v); ok {
+		r.b.WriteString(s)
+		return
+	}
+
+	if v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case error:
+			if !isNil(x) {
+				r.b.WriteString(x.Error())
+				return
+			}
+		case fmt.Stringer:
+			if !isNil(x) {
+				r.b.WriteString(x.String())
+				return
+			}
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			r.b.WriteString("<nil>")
+			return
+		}
+		key := visitKey{ptr: v.Pointer(), typ: v.Type()}
+		if !r.enter(key) {
+			return
+		}
+		r.b.WriteString("&")
+		r.render(v.Elem())
+		delete(r.visiting, key)
+	case reflect.Interface:
+		r.render(v.Elem())
+	case reflect.Struct:
+		if r.tooDeep() && v.NumField() > 0 {
+			r.b.WriteString("{...}")
+			return
+		}
+		r.b.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				r.b.WriteString(" ")
+			}
+			if r.truncated(i, v.NumField()) {
+				break
+			}
+			f := v.Type().Field(i)
+			r.b.WriteString(f.Name)
+			r.b.WriteString(":")
+			if _, redact := fieldTag(f); redact {
+				r.b.WriteString(redacted)
+				continue
+			}
+			r.renderElem("."+f.Name, v.Field(i))
+		}
+		r.b.WriteString("}")
+	case reflect.Array, reflect.Slice:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(&r.b, "%v", v.Bytes())
+			return
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			key := visitKey{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}
+			if !r.enter(key) {
+				return
+			}
+			defer delete(r.visiting, key)
+		}
+		if r.tooDeep() && v.Len() > 0 {
+			r.b.WriteString("[...]")
+			return
+		}
+		r.b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				r.b.WriteString(" ")
+			}
+			if r.truncated(i, v.Len()) {
+				break
+			}
+			r.renderElem(fmt.Sprintf("[%d]", i), v.Index(i))
+		}
+		r.b.WriteString("]")
+	case reflect.Map:
+		if v.Len() > 0 {
+			key := visitKey{ptr: v.Pointer(), typ: v.Type()}
+			if !r.enter(key) {
+				return
+			}
+			defer delete(r.visiting, key)
+		}
+		if r.tooDeep() && v.Len() > 0 {
+			r.b.WriteString("map[...]")
+			return
+		}
+		r.b.WriteString("map[")
+		for i, k := range sortedMapKeys(v) {
+			if i > 0 {
+				r.b.WriteString(" ")
+			}
+			if r.truncated(i, v.Len()) {
+				break
+			}
+			r.render(k)
+			r.b.WriteString(":")
+			r.renderElem(mapKeySegment(k), v.MapIndex(k))
+		}
+		r.b.WriteString("]")
+	case reflect.Bool:
+		r.b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		r.b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32:
+		r.b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		r.b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprintf(&r.b, "%v", v.Complex())
+	case reflect.String:
+		r.b.WriteString(v.String())
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			r.b.WriteString("<nil>")
+			return
+		}
+		fmt.Fprintf(&r.b, "%#x", v.Pointer())
+	default:
+		r.b.WriteString(v.String())
+	}
+}
+
+// sortedMapKeys returns the keys of the map v in a deterministic order:
+// numbers, strings and booleans are sorted by value, and other keys by
+// their rendering.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return mapKeyLess(keys[i], keys[j])
+	})
+	return keys
+}
+
+func mapKeyLess(a, b reflect.Value) bool {
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Bool:
+			return !a.Bool() && b.Bool()
+		}
+	}
+	return formatValue(a) < formatValue(b)
+}
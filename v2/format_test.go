@@ -0,0 +1,207 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type node struct {
+	Value int
+	Next  *node
+}
+
+func TestFormatValue(t *testing.T) {
+	is := New(t)
+
+	is.Equal(formatValue(nil), "<nil>")
+	is.Equal(formatValue(1), "1")
+	is.Equal(formatValue("a b"), "a b")
+	is.Equal(formatValue(1.5), "1.5")
+	is.Equal(formatValue([]int{1, 2}), "[1 2]")
+	is.Equal(formatValue([]byte("ab")), "[97 98]")
+	is.Equal(formatValue(map[int]string{10: "b", 9: "a"}), "map[9:a 10:b]")
+	is.Equal(formatValue(testStruct{v: 1}), "{v:1}")
+	is.Equal(formatValue(&node{Value: 1, Next: &node{Value: 2}}), "&{Value:1 Next:&{Value:2 Next:<nil>}}")
+	is.Equal(formatValue(errors.New("boom")), "boom")
+	is.Equal(formatValue(struct{ D time.Duration }{time.Second}), "{D:1s}")
+	is.Equal(formatValue((*node)(nil)), "<nil>")
+
+	shared := &node{Value: 2}
+	is.Equal(formatValue([]*node{shared, shared}), "[&{Value:2 Next:<nil>} &{Value:2 Next:<nil>}]")
+
+	cyclic := &node{Value: 1}
+	cyclic.Next = &node{Value: 2, Next: cyclic}
+	is.Equal(formatValue(cyclic), "&{Value:1 Next:&{Value:2 Next:<cycle to node>}}")
+
+	m := map[string]interface{}{"a": 1}
+	m["self"] = m
+	is.Equal(formatValue(m), "map[a:1 self:<cycle to (root)>]")
+
+	s := []interface{}{1, nil}
+	s[1] = s
+	is.Equal(formatValue(struct{ S []interface{} }{s}), "{S:[1 <cycle to S>]}")
+}
+
+func TestEqualCycle(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = format
+	})
+
+	a := &node{Value: 1}
+	a.Next = &node{Value: 2, Next: a}
+	b := &node{Value: 1}
+	b.Next = &node{Value: 2, Next: b}
+	is.Equal(a, b)
+	is.Strict().Equal(hit, 0)
+
+	b.Next.Next = &node{Value: 3, Next: b}
+	is.Equal(a, b)
+	is.Strict().Equal(hit, 1)
+	is.Equal(msg, "objects of type '%s' differ at %s%s")
+
+	equal, c := compareObjects(a, b, equalOptions{}, formatOptions{})
+	is.False(equal)
+	is.Equal(c.details(), " (node.Next.Next.Value: got 1, want 3)")
+
+	c2 := &node{Value: 1}
+	c2.Next = &node{Value: 2, Next: c2}
+	equal, c = compareObjects(a, &node{Value: 1, Next: &node{Value: 2, Next: c2}}, equalOptions{}, formatOptions{})
+	is.True(equal)
+	is.Equal(c.details(), " (cycle detected at node.Next.Next.Next.Next)")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 1)
+
+	// values shared without forming a cycle are not cycles
+	p, q := &node{Value: 1}, &node{Value: 1}
+	equal, c = compareObjects([]*node{p, p}, []*node{q, q}, equalOptions{}, formatOptions{})
+	is.True(equal)
+	is.Equal(c.details(), "")
+	equal, c = compareObjects([]*node{p, p}, []*node{q, {Value: 2}}, equalOptions{}, formatOptions{})
+	is.False(equal)
+	is.Equal(c.details(), " ([1].Value: got 1, want 2)")
+
+	// shared values are compared once, instead of once per path to them
+	type dag struct{ L, R *dag }
+	var x, y *dag
+	for i := 0; i < 64; i++ {
+		x = &dag{x, x}
+		y = &dag{y, y}
+	}
+	is.Equal(x, y)
+}
+
+func TestFormatLimits(t *testing.T) {
+	is := New(t)
+
+	big := map[int][]int{}
+	for i := 0; i < 10; i++ {
+		big[i] = []int{i, i * 2, i * 3}
+	}
+	is.Equal(formatOptions{maxWidth: 2}.format(big), "map[0:[0 0 ... (1 more)] 1:[1 2 ... (1 more)] ... (8 more)]")
+	is.Equal(formatOptions{maxDepth: 1}.format(big), "map[0:[...] 1:[...] 2:[...] 3:[...] 4:[...] 5:[...] 6:[...] 7:[...] 8:[...] 9:[...]]")
+	is.Equal(formatOptions{maxDepth: 1, maxWidth: 1}.format(&node{Value: 1, Next: &node{}}), "&{Value:1 ... (1 more)}")
+	is.Equal(formatOptions{maxDepth: 1}.format(&node{Value: 1, Next: &node{}}), "&{Value:1 Next:&{...}}")
+	is.Equal(formatOptions{maxDepth: 1}.format([]int{}), "[]")
+
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.FormatLimits(0, 3).Nil([]int{1, 2, 3, 4, 5})
+	is = is.WithFailFunc(nil)
+	is.Equal(msg, "expected object '[]int' to be nil, but got: [1 2 3 ... (2 more)]")
+}
+
+func TestMapDiffDeterministic(t *testing.T) {
+	is := New(t)
+
+	a := map[string]int{}
+	b := map[string]int{}
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("k%02d", i)
+		a[key] = i
+		b[key] = -i
+	}
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		msg = fmt.Sprintf(format, args...)
+	})
+	for i := 0; i < 20; i++ {
+		is.Equal(a, b)
+		if msg != `objects of type 'map[string]int' differ at ["k01"]: got 1, want -1` {
+			t.Fatalf("unexpected message: %s", msg)
+		}
+	}
+}
+
+type celsius float64
+
+func TestRegisterRenderer(t *testing.T) {
+	is := New(t)
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	is.Equal(formatValue(at), "2024-01-02T03:04:05Z")
+	is.Equal(formatValue(struct {
+		d  time.Duration
+		at time.Time
+	}{1500 * time.Millisecond, at}), "{d:1.5s at:2024-01-02T03:04:05Z}")
+	is.Equal(formatValue(map[string]time.Duration{"a": time.Minute}), "map[a:1m0s]")
+
+	is.Equal(ByteSize(512).String(), "512B")
+	is.Equal(ByteSize(1536).String(), "1.5KiB")
+	is.Equal(ByteSize(1<<20).String(), "1MiB")
+	is.Equal(ByteSize(4404019).String(), "4.2MiB")
+	is.Equal(ByteSize(-2048).String(), "-2KiB")
+	is.Equal(ByteSize(1023).String(), "1023B")
+	is.Equal(ByteSize(1024).String(), "1KiB")
+	is.Equal(ByteSize(1048524).String(), "1023.9KiB")
+	is.Equal(ByteSize(1048575).String(), "1MiB")
+	is.Equal(ByteSize(-1048575).String(), "-1MiB")
+	is.Equal(ByteSize(1<<30-1).String(), "1GiB")
+	is.Equal(formatValue(struct{ size ByteSize }{3 << 30}), "{size:3GiB}")
+
+	RegisterRenderer(func(c celsius) string { return fmt.Sprintf("%.1f°C", float64(c)) })
+	is.Equal(formatValue([]celsius{21.5}), "[21.5°C]")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Equal(at, at.Add(time.Hour))
+	is.Equal(msg, "got '2024-01-02T03:04:05Z' (time.Time). expected '2024-01-02T04:04:05Z' (time.Time)")
+	type event struct{ At time.Time }
+	is.EqualExported(event{at}, event{at.Add(time.Second)})
+	is.Equal(msg, "objects of type 'is.event' differ at event.At: got 2024-01-02T03:04:05Z, want 2024-01-02T03:04:06Z")
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+}
+
+func TestNoDiff(t *testing.T) {
+	is := New(t)
+
+	big := strings.Repeat("x", 1<<20)
+	c := NewCollector()
+	quiet := c.NoDiff().WithVerbosity(VerbosityVerbose)
+	quiet.Equal(big, big+"y")
+	quiet.Equal([]string{"a", big, "c"}, []string{"a", big + "y", "d"})
+	quiet.Equal(1, 2)
+	errs := c.Errs()
+	is.Len(errs, 3)
+	is.ErrMsg(errs[0], "[Equal] got string of length 1048576, expected string of length 1048577")
+	is.ErrMsg(errs[1], "[Equal] objects of type '[]string' differ at [1] (2 places)")
+	is.ErrMsg(errs[2], "[Equal] got int, expected int")
+	failures := c.Failures()
+	is.Nil(failures[1].Actual)
+	is.Equal(failures[1].Diff, "")
+}
@@ -0,0 +1,153 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// typeName returns the name of the type parameter T. Unlike objectTypeName,
+// it works for interface types as well, since it does not need a value.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// Cast checks that the provided object is of type T (or implements T if T is
+// an interface) and returns it as a T. If it is not, the assertion fails and
+// the zero value of T is returned.
+//
+// This replaces the common pattern
+//
+//	x, ok := v.(T)
+//	is.True(ok)
+//
+// which gives no hint about the actual type on failure. It is the generic
+// counterpart of EqualType, which needs a value of the expected type.
+func Cast[T any](is *Is, o interface{}) T {
+	is.TB.Helper()
+	v, ok := o.(T)
+	if !ok {
+		is.fail("expected object to be of type '%s', but got '%s'",
+			typeName[T](), objectTypeName(o))
+		return v
+	}
+	passed(is)
+	return v
+}
+
+// NilT checks the provided pointer to determine if it is nil.
+//
+// Since the argument is a typed pointer rather than an interface{}, there is
+// no way to fall into the trap of a nil pointer wrapped in a non-nil interface.
+func NilT[T any](is *Is, p *T) bool {
+	is.TB.Helper()
+	if p != nil {
+		is.fail("expected pointer '*%s' to be nil, but got: %v", typeName[T](), p)
+		return false
+	}
+	return passed(is)
+}
+
+// NotNilT checks the provided pointer to determine if it is not nil.
+func NotNilT[T any](is *Is, p *T) bool {
+	is.TB.Helper()
+	if p == nil {
+		is.fail("expected pointer '*%s' not to be nil", typeName[T]())
+		return false
+	}
+	return passed(is)
+}
+
+// OneOfT checks the provided value to determine if it is equal to one of the
+// candidates, using ==. Unlike OneOf, it does not use reflection and the
+// types are checked at compile time.
+func OneOfT[T comparable](is *Is, v T, candidates ...T) bool {
+	is.TB.Helper()
+	for _, c := range candidates {
+		if v == c {
+			return passed(is)
+		}
+	}
+	is.fail("expected '%s' value to be one of %v, but got: %v", typeName[T](), candidates, v)
+	return false
+}
+
+// In checks the provided value to determine if it is an element of set,
+// using ==. It is like OneOfT, but takes the candidates as a slice.
+func In[T comparable](is *Is, v T, set []T) bool {
+	is.TB.Helper()
+	for _, c := range set {
+		if v == c {
+			return passed(is)
+		}
+	}
+	is.fail("expected '%s' value to be in %v, but got: %v", typeName[T](), set, v)
+	return false
+}
+
+// EqualSortedT is like EqualSorted, for slices whose element type is
+// checked at compile time.
+func EqualSortedT[T any](is *Is, actual []T, expected []T, less func(a, b T) bool) bool {
+	is.TB.Helper()
+	sorted := func(s []T) []T {
+		cp := make([]T, len(s))
+		copy(cp, s)
+		sort.SliceStable(cp, func(i, j int) bool { return less(cp[i], cp[j]) })
+		return cp
+	}
+	return is.equalAfter(sorted(actual), sorted(expected), "sorting")
+}
+
+// SliceEqualFunc checks that the provided slices have the same length and
+// that eq returns true for each pair of elements at the same index. The
+// failure message gives the index of the first mismatch. Unlike Equal, it
+// does not use reflection.
+func SliceEqualFunc[T any](is *Is, actual []T, expected []T, eq func(a, b T) bool) bool {
+	is.TB.Helper()
+	if len(actual) != len(expected) {
+		is.fail("slices of '%s' differ in length: got %d, want %d", typeName[T](), len(actual), len(expected))
+		return false
+	}
+	for i := range actual {
+		if !eq(actual[i], expected[i]) {
+			is.fail("slices of '%s' differ at index %d: got %v, want %v", typeName[T](), i, actual[i], expected[i])
+			return false
+		}
+	}
+	return passed(is)
+}
+
+// MapEqualFunc checks that the provided maps have the same keys and that eq
+// returns true for the values of each key. The failure message gives the
+// first key whose value differs, is missing or is unexpected, with keys in
+// the order of their fmt.Sprint representation. Unlike Equal, it does not
+// use reflection.
+func MapEqualFunc[K comparable, V any](is *Is, actual map[K]V, expected map[K]V, eq func(a, b V) bool) bool {
+	is.TB.Helper()
+	type mismatch struct{ key, desc string }
+	var mismatches []mismatch
+	add := func(k K, format string, args ...interface{}) {
+		mismatches = append(mismatches, mismatch{fmt.Sprint(k), fmt.Sprintf(format, args...)})
+	}
+	for k, e := range expected {
+		a, ok := actual[k]
+		switch {
+		case !ok:
+			add(k, "missing key %v", k)
+		case !eq(a, e):
+			add(k, "key %v: got %v, want %v", k, a, e)
+		}
+	}
+	for k, a := range actual {
+		if _, ok := expected[k]; !ok {
+			add(k, "unexpected key %v with %v", k, a)
+		}
+	}
+	if len(mismatches) > 0 {
+		sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].key < mismatches[j].key })
+		is.fail("maps of '%s' to '%s' differ at %d keys, first at %s",
+			typeName[K](), typeName[V](), len(mismatches), mismatches[0].desc)
+		return false
+	}
+	return passed(is)
+}
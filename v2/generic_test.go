@@ -0,0 +1,106 @@
+package is
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCast(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.Equal(Cast[int](is, 5), 5)
+	is.Equal(Cast[string](is, "test"), "test")
+	is.Equal(Cast[*testStruct](is, &testStruct{v: 1}).v, 1)
+	is.Equal(Cast[error](is, errors.New("error")).Error(), "error")
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	is.Equal(Cast[int](is, "5"), 0)
+	is.Equal(Cast[string](is, nil), "")
+	is.Nil(Cast[error](is, 5))
+	is.Equal(Cast[fmt.Stringer](is, 5), nil)
+	is.Equal(Cast[*testStruct](is, testStruct{}), (*testStruct)(nil))
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 5)
+	is.Equal(Cast[error](is, errors.New("error")).Error(), "error")
+}
+
+func TestNilT(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	is.True(NilT(is, (*testStruct)(nil)))
+	is.True(NotNilT(is, &testStruct{}))
+	is.False(NilT(is, &testStruct{}))
+	is.False(NotNilT[int](is, nil))
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+}
+
+func TestOneOfT(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.True(OneOfT(is, "b", "a", "b", "c"))
+	is.True(In(is, 2, []int{1, 2, 3}))
+	is.True(OneOfT(is, testStruct{v: 1}, testStruct{}, testStruct{v: 1}))
+	is.Strict().Equal(hit, 0)
+
+	is.False(OneOfT(is, "d", "a", "b", "c"))
+	is.Strict().Equal(msg, "expected 'string' value to be one of [a b c], but got: d")
+	is.False(In(is, 4, []int{1, 2, 3}))
+	is.Strict().Equal(msg, "expected 'int' value to be in [1 2 3], but got: 4")
+	is.False(In(is, 1, nil))
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 3)
+}
+
+func TestSliceEqualFunc(t *testing.T) {
+	is := New(t)
+
+	sameLength := func(a, b string) bool { return len(a) == len(b) }
+	SliceEqualFunc(is, []string{"a", "bc"}, []string{"x", "yz"}, sameLength)
+	SliceEqualFunc(is, nil, []string{}, sameLength)
+
+	c := NewCollector()
+	SliceEqualFunc(c.Is, []string{"a", "bc"}, []string{"x"}, sameLength)
+	SliceEqualFunc(c.Is, []string{"a", "bc", "d"}, []string{"x", "y", "zz"}, sameLength)
+	errs := c.Errs()
+	is.Len(errs, 2)
+	is.ErrMsg(errs[0], "[SliceEqualFunc] slices of 'string' differ in length: got 2, want 1")
+	is.ErrMsg(errs[1], "[SliceEqualFunc] slices of 'string' differ at index 1: got bc, want y")
+}
+
+func TestMapEqualFunc(t *testing.T) {
+	is := New(t)
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+	MapEqualFunc(is, map[string]float64{"a": 1, "b": 2.001}, map[string]float64{"a": 1.001, "b": 2}, near)
+
+	c := NewCollector()
+	MapEqualFunc(c.Is, map[string]float64{"a": 1, "c": 3}, map[string]float64{"a": 1, "b": 2}, near)
+	MapEqualFunc(c.Is, map[int]float64{1: 1, 2: 3}, map[int]float64{1: 1, 2: 2}, near)
+	errs := c.Errs()
+	is.Len(errs, 2)
+	is.ErrMsg(errs[0], "[MapEqualFunc] maps of 'string' to 'float64' differ at 2 keys, first at missing key b")
+	is.ErrMsg(errs[1], "[MapEqualFunc] maps of 'int' to 'float64' differ at 1 keys, first at key 2: got 3, want 2")
+}
@@ -0,0 +1,30 @@
+package is
+
+// GotValue is an actual value waiting for the expected value to compare it
+// to. It is created with Is.Got.
+type GotValue struct {
+	is     *Is
+	actual interface{}
+}
+
+// Got starts a comparison labeled at the call site, for code where the
+// order of the arguments of Equal is unclear:
+//
+//	is.Got(resp.StatusCode).Want(http.StatusOK)
+func (is *Is) Got(actual interface{}) *GotValue {
+	return &GotValue{is: is, actual: actual}
+}
+
+// Want checks the value passed to Got to determine if it is equal to
+// expected, like Equal.
+func (g *GotValue) Want(expected interface{}) bool {
+	g.is.TB.Helper()
+	return g.is.Equal(g.actual, expected)
+}
+
+// WantNot checks the value passed to Got to determine if it is not equal
+// to unexpected, like NotEqual.
+func (g *GotValue) WantNot(unexpected interface{}) bool {
+	g.is.TB.Helper()
+	return g.is.NotEqual(g.actual, unexpected)
+}
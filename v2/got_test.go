@@ -0,0 +1,24 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGotWant(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Got(1).Want(1)
+	is.Got(1).WantNot(2)
+	is.Got("a").Want("b")
+
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, 1)
+	is.Equal(msg, "got 'a' (string). expected 'b' (string)")
+}
@@ -0,0 +1,32 @@
+package is
+
+// Named returns a copy of this Is which prefixes its failure messages with
+// name, and indents the following lines of multi-line messages such as
+// diffs. The copy inherits the message, separator and strictness of this
+// Is, which can be overridden independently, for tests exercising several
+// instances of the same component side by side:
+//
+//	a, b := is.Named("client A"), is.Named("client B").Lax()
+//
+// Names of nested copies are separated by "/".
+func (is *Is) Named(name string) *Is {
+	newIs := *is
+	if newIs.group != "" {
+		name = newIs.group + "/" + name
+	}
+	newIs.group = name
+	return &newIs
+}
+
+// Group calls fn with a copy of this Is named like with Named, which
+// prefixes its failure messages with name. This keeps the output readable
+// when checking a large value field by field, without running subtests:
+//
+//	is.Group("address", func(is *is.Is) {
+//		is.Equal(user.Address.City, "Paris")
+//		is.Equal(user.Address.Zip, "75001")
+//	})
+func (is *Is) Group(name string, fn func(is *Is)) {
+	is.TB.Helper()
+	fn(is.Named(name))
+}
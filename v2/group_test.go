@@ -0,0 +1,39 @@
+package is
+
+import (
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	c.Group("user", func(c *Is) {
+		c.Equal(1, 1)
+		c.Equal("bob", "alice")
+		c.Group("address", func(c *Is) {
+			c.WithContext("id", 7).True(false)
+		})
+	})
+	c.True(false)
+	is.Len(c.Errs(), 3)
+	is.ErrMsg(c.Errs()[0], "[Equal] user: got 'bob' (string). expected 'alice' (string)")
+	is.ErrMsg(c.Errs()[1], "[True] user/address: expected boolean to be true\n\t\tid: 7")
+	is.ErrMsg(c.Errs()[2], "[True] expected boolean to be true")
+	is.Equal(c.Failures()[1].Message, "[True] user/address: expected boolean to be true\n\t\tid: 7")
+}
+
+func TestNamed(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	a := c.Msg("request %d", 1).Named("client A")
+	b := a.Named("retry").MsgSep(" | ").Strict()
+	a.Equal(1, 2)
+	b.True(false)
+	c.True(false)
+	is.Len(c.Errs(), 3)
+	is.ErrMsg(c.Errs()[0], "[Equal] client A: got '1' (int). expected '2' (int) - request 1")
+	is.ErrMsg(c.Errs()[1], "[True] client A/retry: expected boolean to be true | request 1")
+	is.ErrMsg(c.Errs()[2], "[True] expected boolean to be true")
+}
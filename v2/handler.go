@@ -0,0 +1,136 @@
+package is
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// handlerTB is a testing.TB which passes failures to a handler function,
+// and logs to a separate log function, if any. It embeds a nil testing.TB
+// to satisfy the interface, so calling a method it does not implement
+// panics.
+type handlerTB struct {
+	testing.TB
+	handler func(format string, args ...interface{})
+	log     func(format string, args ...interface{})
+
+	mu     sync.Mutex
+	failed bool
+}
+
+// NewHandlerTB returns a minimal testing.TB which passes every failure to
+// the provided handler, for use outside of go test. Errorf, Fatalf, Error,
+// Fatal, Fail, FailNow, Failed, Log, Logf, Helper and Name are supported.
+// Fatalf and FailNow do not stop the program; the handler may do so, for
+// example by calling log.Fatalf. Logs, such as the failures of assertions
+// made with Warn, are not failures and are dropped.
+//
+// Cleanup, Setenv, Skip, Skipf, SkipNow and TempDir need go test, so they
+// report a failure saying so to the handler instead.
+func NewHandlerTB(h func(format string, args ...interface{})) testing.TB {
+	if h == nil {
+		panic("You must provide a handler function.")
+	}
+	return &handlerTB{handler: h}
+}
+
+// NewWithHandler creates a new Is which passes failures to the provided
+// handler instead of a testing object. This allows using assertions in
+// example programs, integration harnesses and smoke test binaries which do
+// not run under go test.
+//
+// For example:
+//
+//	is := is.NewWithHandler(log.Printf)
+//	is.Equal(resp.StatusCode, http.StatusOK)
+//	if is.TB.Failed() {
+//		os.Exit(1)
+//	}
+func NewWithHandler(h func(format string, args ...interface{})) *Is {
+	return New(NewHandlerTB(h))
+}
+
+func (tb *handlerTB) Helper() {}
+
+func (tb *handlerTB) Name() string {
+	return ""
+}
+
+func (tb *handlerTB) Fail() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.failed = true
+}
+
+func (tb *handlerTB) FailNow() {
+	tb.Fail()
+}
+
+func (tb *handlerTB) Failed() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.failed
+}
+
+func (tb *handlerTB) Errorf(format string, args ...interface{}) {
+	tb.Fail()
+	tb.handler(format, args...)
+}
+
+func (tb *handlerTB) Fatalf(format string, args ...interface{}) {
+	tb.Errorf(format, args...)
+}
+
+func (tb *handlerTB) Error(args ...interface{}) {
+	tb.Errorf("%s", fmt.Sprint(args...))
+}
+
+func (tb *handlerTB) Fatal(args ...interface{}) {
+	tb.Errorf("%s", fmt.Sprint(args...))
+}
+
+func (tb *handlerTB) Log(args ...interface{}) {
+	tb.Logf("%s", fmt.Sprint(args...))
+}
+
+func (tb *handlerTB) Logf(format string, args ...interface{}) {
+	if tb.log != nil {
+		tb.log(format, args...)
+	}
+}
+
+// unsupported reports a failure for a method of testing.TB which needs go
+// test.
+func (tb *handlerTB) unsupported(method string) {
+	tb.Errorf("%s is not supported outside of go test", method)
+}
+
+func (tb *handlerTB) Cleanup(func()) {
+	tb.unsupported("Cleanup")
+}
+
+func (tb *handlerTB) Setenv(key, value string) {
+	tb.unsupported("Setenv")
+}
+
+func (tb *handlerTB) Skip(args ...interface{}) {
+	tb.unsupported("Skip")
+}
+
+func (tb *handlerTB) Skipf(format string, args ...interface{}) {
+	tb.unsupported("Skipf")
+}
+
+func (tb *handlerTB) SkipNow() {
+	tb.unsupported("SkipNow")
+}
+
+func (tb *handlerTB) Skipped() bool {
+	return false
+}
+
+func (tb *handlerTB) TempDir() string {
+	tb.unsupported("TempDir")
+	return ""
+}
@@ -0,0 +1,50 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewWithHandler(t *testing.T) {
+	is := New(t)
+
+	var msgs []string
+	h := NewWithHandler(func(format string, args ...interface{}) {
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	})
+	is.False(h.TB.Failed())
+	is.True(h.Equal(1, 1))
+	is.False(h.TB.Failed())
+	is.False(h.Msg("id %d", 7).Equal(1, 2))
+	is.False(h.True(false))
+	is.True(h.TB.Failed())
+	is.Equal(msgs, []string{
+		"[Equal] got '1' (int). expected '2' (int) - id 7",
+		"[True] expected boolean to be true",
+	})
+
+	msgs = nil
+	is.False(h.Warn().Equal(1, 2))
+	is.Len(msgs, 0)
+
+	tb := h.TB
+	tb.Cleanup(func() {})
+	tb.Setenv("IS_TEST_HANDLER", "1")
+	tb.Skip("x")
+	tb.Skipf("%s", "x")
+	tb.SkipNow()
+	is.False(tb.Skipped())
+	is.Equal(tb.TempDir(), "")
+	is.Equal(msgs, []string{
+		"Cleanup is not supported outside of go test",
+		"Setenv is not supported outside of go test",
+		"Skip is not supported outside of go test",
+		"Skipf is not supported outside of go test",
+		"SkipNow is not supported outside of go test",
+		"TempDir is not supported outside of go test",
+	})
+
+	is.ShouldPanic(func() {
+		NewWithHandler(nil)
+	})
+}
@@ -0,0 +1,164 @@
+package is
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+)
+
+// readBody reads the whole body of the response and replaces it with a new
+// reader over the same bytes, so it can be read again by further assertions
+// or by the code under test.
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+// serveHTTP serves the request with the handler and returns the recorded
+// response.
+func serveHTTP(h http.Handler, r *http.Request) *http.Response {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+	return rec.Result()
+}
+
+// HTTPStatus checks the status code of the provided response.
+func (is *Is) HTTPStatus(resp *http.Response, code int) bool {
+	is.TB.Helper()
+	if resp == nil {
+		is.fail("expected response with status %d, but response is nil", code)
+		return false
+	}
+	if resp.StatusCode != code {
+		body, _ := readBody(resp)
+		is.fail("expected status %d, but got %d. body: %s", code, resp.StatusCode, head(string(body), 200))
+		return false
+	}
+	return passed(is)
+}
+
+// HTTPHeader checks the provided response to determine if the header key is
+// set to value.
+func (is *Is) HTTPHeader(resp *http.Response, key string, value string) bool {
+	is.TB.Helper()
+	if resp == nil {
+		is.fail("expected response with header %s, but response is nil", key)
+		return false
+	}
+	values, ok := resp.Header[http.CanonicalHeaderKey(key)]
+	if !ok {
+		is.fail("expected header %s to be %q, but it is not set", key, value)
+		return false
+	}
+	for _, v := range values {
+		if v == value {
+			return passed(is)
+		}
+	}
+	is.fail("expected header %s to be %q, but got: %q", key, value, values)
+	return false
+}
+
+// HTTPBodyContains checks the body of the provided response to determine if
+// it contains substr. The body is restored, so it can be read again.
+func (is *Is) HTTPBodyContains(resp *http.Response, substr string) bool {
+	is.TB.Helper()
+	if resp == nil {
+		is.fail("expected response body to contain %q, but response is nil", substr)
+		return false
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		is.fail("failed to read response body: %v", err)
+		return false
+	}
+	if !strings.Contains(string(body), substr) {
+		is.fail("expected response body to contain %q, but got: %s", substr, body)
+		return false
+	}
+	return passed(is)
+}
+
+// HTTPJSONBody checks the body of the provided response to determine if it is
+// a JSON document equal to expected, ignoring formatting and object key
+// order. If expected is a string or a []byte, it is parsed as JSON, otherwise
+// it is marshaled to JSON first. The body is restored, so it can be read
+// again.
+func (is *Is) HTTPJSONBody(resp *http.Response, expected interface{}) bool {
+	is.TB.Helper()
+	if resp == nil {
+		is.fail("expected JSON response body, but response is nil")
+		return false
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		is.fail("failed to read response body: %v", err)
+		return false
+	}
+	a, err := decodeJSON(body)
+	if err != nil {
+		is.fail("expected response body to be JSON: %v. body: %s", err, body)
+		return false
+	}
+	e, err := decodeJSON(expected)
+	if err != nil {
+		is.fail("expected value is not valid JSON: %v", err)
+		return false
+	}
+	if !reflect.DeepEqual(a, e) {
+		is.fail("got JSON body %s. expected %s", encodeJSON(a), encodeJSON(e))
+		return false
+	}
+	return passed(is)
+}
+
+// HTTPSuccess serves the request with the handler and checks that the
+// response status code is 2xx.
+func (is *Is) HTTPSuccess(h http.Handler, r *http.Request) bool {
+	is.TB.Helper()
+	resp := serveHTTP(h, r)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		is.fail("expected success status for %s %s, but got %d", r.Method, r.URL, resp.StatusCode)
+		return false
+	}
+	return passed(is)
+}
+
+// HTTPRedirect serves the request with the handler and checks that the
+// response status code is 3xx.
+func (is *Is) HTTPRedirect(h http.Handler, r *http.Request) bool {
+	is.TB.Helper()
+	resp := serveHTTP(h, r)
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+		is.fail("expected redirect status for %s %s, but got %d", r.Method, r.URL, resp.StatusCode)
+		return false
+	}
+	return passed(is)
+}
+
+// HTTPError serves the request with the handler and checks that the response
+// status code is 4xx or 5xx.
+func (is *Is) HTTPError(h http.Handler, r *http.Request) bool {
+	is.TB.Helper()
+	resp := serveHTTP(h, r)
+	if resp.StatusCode < 400 {
+		is.fail("expected error status for %s %s, but got %d", r.Method, r.URL, resp.StatusCode)
+		return false
+	}
+	return passed(is)
+}
+
+// HTTPHandlerStatus serves the request with the handler and checks the
+// response status code.
+func (is *Is) HTTPHandlerStatus(h http.Handler, r *http.Request, code int) bool {
+	is.TB.Helper()
+	return is.HTTPStatus(serveHTTP(h, r), code)
+}
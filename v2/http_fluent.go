@@ -0,0 +1,170 @@
+package is
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+// HTTPTester sends requests to an http.Handler using httptest. It is created
+// with Is.HTTP.
+type HTTPTester struct {
+	is      *Is
+	handler http.Handler
+}
+
+// HTTPRequest is a request being built by an HTTPTester. It is sent to the
+// handler by calling Expect.
+type HTTPRequest struct {
+	is      *Is
+	handler http.Handler
+	req     *http.Request
+}
+
+// HTTPResponse is a response recorded from a handler, with chainable
+// assertions on it. Failure messages include the request method and URL, in
+// addition to any message set on the Is object with Msg or AddMsg.
+type HTTPResponse struct {
+	is   *Is
+	resp *http.Response
+}
+
+// HTTP returns an HTTPTester which sends requests to the handler, for
+// example:
+//
+//	is.HTTP(handler).Get("/users/1").
+//		WithHeader("Accept", "application/json").
+//		Expect().
+//		Status(200).
+//		JSONPath("$.name", "bob")
+//
+// Assertions on the response respect the strict or lax mode of the Is object.
+func (is *Is) HTTP(h http.Handler) *HTTPTester {
+	return &HTTPTester{is: is, handler: h}
+}
+
+// Request starts building a request with the provided method, target and
+// body, which may be nil.
+func (h *HTTPTester) Request(method string, target string, body io.Reader) *HTTPRequest {
+	return &HTTPRequest{
+		is:      h.is,
+		handler: h.handler,
+		req:     httptest.NewRequest(method, target, body),
+	}
+}
+
+// Get starts building a GET request.
+func (h *HTTPTester) Get(target string) *HTTPRequest {
+	return h.Request(http.MethodGet, target, nil)
+}
+
+// Head starts building a HEAD request.
+func (h *HTTPTester) Head(target string) *HTTPRequest {
+	return h.Request(http.MethodHead, target, nil)
+}
+
+// Delete starts building a DELETE request.
+func (h *HTTPTester) Delete(target string) *HTTPRequest {
+	return h.Request(http.MethodDelete, target, nil)
+}
+
+// Post starts building a POST request with the provided body.
+func (h *HTTPTester) Post(target string, body io.Reader) *HTTPRequest {
+	return h.Request(http.MethodPost, target, body)
+}
+
+// Put starts building a PUT request with the provided body.
+func (h *HTTPTester) Put(target string, body io.Reader) *HTTPRequest {
+	return h.Request(http.MethodPut, target, body)
+}
+
+// Patch starts building a PATCH request with the provided body.
+func (h *HTTPTester) Patch(target string, body io.Reader) *HTTPRequest {
+	return h.Request(http.MethodPatch, target, body)
+}
+
+// WithHeader sets a header on the request.
+func (r *HTTPRequest) WithHeader(key string, value string) *HTTPRequest {
+	r.req.Header.Set(key, value)
+	return r
+}
+
+// WithJSON sets the request body to the JSON encoding of v, along with the
+// Content-Type header. It fails if v can not be marshaled.
+func (r *HTTPRequest) WithJSON(v interface{}) *HTTPRequest {
+	r.is.TB.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		r.is.fail("failed to marshal request body: %v", err)
+		return r
+	}
+	r.req.Body = io.NopCloser(bytes.NewReader(data))
+	r.req.ContentLength = int64(len(data))
+	r.req.Header.Set("Content-Type", "application/json")
+	return r
+}
+
+// Request returns the underlying request, for changes not covered by the
+// other methods.
+func (r *HTTPRequest) Request() *http.Request {
+	return r.req
+}
+
+// Expect sends the request to the handler and returns the recorded response
+// for making assertions on it.
+func (r *HTTPRequest) Expect() *HTTPResponse {
+	return &HTTPResponse{
+		is:   r.is.AddMsg("%s %s", r.req.Method, r.req.URL),
+		resp: serveHTTP(r.handler, r.req),
+	}
+}
+
+// Response returns the recorded response.
+func (r *HTTPResponse) Response() *http.Response {
+	return r.resp
+}
+
+// Status checks the status code of the response.
+func (r *HTTPResponse) Status(code int) *HTTPResponse {
+	r.is.TB.Helper()
+	r.is.HTTPStatus(r.resp, code)
+	return r
+}
+
+// Header checks the response to determine if the header key is set to value.
+func (r *HTTPResponse) Header(key string, value string) *HTTPResponse {
+	r.is.TB.Helper()
+	r.is.HTTPHeader(r.resp, key, value)
+	return r
+}
+
+// BodyContains checks the response body to determine if it contains substr.
+func (r *HTTPResponse) BodyContains(substr string) *HTTPResponse {
+	r.is.TB.Helper()
+	r.is.HTTPBodyContains(r.resp, substr)
+	return r
+}
+
+// JSONBody checks the response body to determine if it is a JSON document
+// equal to expected. See Is.HTTPJSONBody.
+func (r *HTTPResponse) JSONBody(expected interface{}) *HTTPResponse {
+	r.is.TB.Helper()
+	r.is.HTTPJSONBody(r.resp, expected)
+	return r
+}
+
+// JSONPath checks the response body to determine if it is a JSON document
+// whose element at path, such as "$.items[2].id", is equal to expected once
+// marshaled to JSON.
+func (r *HTTPResponse) JSONPath(path string, expected interface{}) *HTTPResponse {
+	r.is.TB.Helper()
+	body, err := readBody(r.resp)
+	if err != nil {
+		r.is.fail("failed to read response body: %v", err)
+		return r
+	}
+	r.is.JSONPath(body, path, expected)
+	return r
+}
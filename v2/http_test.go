@@ -0,0 +1,104 @@
+package is
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/user":
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "bob", "tags": ["a", "b"], "age": 30}`)
+	case "/old":
+		http.Redirect(w, r, "/user", http.StatusMovedPermanently)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	is := New(t)
+	h := http.HandlerFunc(testHandler)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	resp := serveHTTP(h, httptest.NewRequest("GET", "/user", nil))
+	is.HTTPStatus(resp, 200)
+	is.HTTPHeader(resp, "content-type", "application/json")
+	is.HTTPBodyContains(resp, `"bob"`)
+	is.HTTPJSONBody(resp, `{"age": 30, "tags": ["a", "b"], "name": "bob"}`)
+	is.HTTPJSONBody(resp, map[string]interface{}{"name": "bob", "tags": []string{"a", "b"}, "age": 30})
+	body, err := io.ReadAll(resp.Body)
+	is.NotErr(err)
+	is.Equal(string(body), `{"name": "bob", "tags": ["a", "b"], "age": 30}`)
+	is.HTTPSuccess(h, httptest.NewRequest("GET", "/user", nil))
+	is.HTTPRedirect(h, httptest.NewRequest("GET", "/old", nil))
+	is.HTTPError(h, httptest.NewRequest("GET", "/missing", nil))
+	is.HTTPHandlerStatus(h, httptest.NewRequest("GET", "/missing", nil), 404)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	is.HTTPStatus(resp, 201)
+	is.HTTPStatus(nil, 200)
+	is.HTTPHeader(resp, "Content-Type", "text/plain")
+	is.HTTPHeader(resp, "X-Missing", "")
+	is.HTTPBodyContains(resp, "alice")
+	is.HTTPJSONBody(resp, `{"name": "alice"}`)
+	is.HTTPJSONBody(resp, `{`)
+	is.HTTPSuccess(h, httptest.NewRequest("GET", "/missing", nil))
+	is.HTTPRedirect(h, httptest.NewRequest("GET", "/user", nil))
+	is.HTTPError(h, httptest.NewRequest("GET", "/user", nil))
+	is.HTTPHandlerStatus(h, httptest.NewRequest("GET", "/user", nil), 404)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 11)
+}
+
+func TestHTTPFluent(t *testing.T) {
+	is := New(t)
+	h := http.HandlerFunc(testHandler)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.HTTP(h).Get("/user").
+		WithHeader("Accept", "application/json").
+		Expect().
+		Status(200).
+		Header("Content-Type", "application/json").
+		BodyContains("bob").
+		JSONPath("$.name", "bob").
+		JSONPath("tags[1]", "b").
+		JSONPath("$.age", 30)
+	is.HTTP(h).Post("/missing", nil).WithJSON(map[string]int{"a": 1}).Expect().Status(404)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+		if m := is.message(); m != "" {
+			msg += " - " + m
+		}
+	})
+	is.HTTP(h).Get("/user").Expect().
+		Status(201).
+		JSONPath("$.name", "alice").
+		JSONPath("$.tags[5]", "a").
+		JSONPath("$.missing", 1).
+		JSONPath("$.name.first", "bob")
+	is.Equal(msg, `$.name is not an object: "bob" - GET /user`)
+	is.HTTP(h).Get("/old").Expect().JSONPath("$", nil)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 6)
+}
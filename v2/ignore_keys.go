@@ -0,0 +1,111 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// keyScrubber removes keys from maps nested in values, for
+// EqualIgnoringKeys.
+type keyScrubber struct {
+	// anywhere holds the keys removed at every depth.
+	anywhere []interface{}
+}
+
+// scrub returns a copy of v without the keys of s, and without the keys
+// at the given paths, relative to v. Maps and slices are copied, v is not
+// modified.
+func (s *keyScrubber) scrub(v reflect.Value, paths [][]string) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		scrubbed := s.scrub(v.Elem(), paths)
+		out := reflect.New(v.Type()).Elem()
+		out.Set(scrubbed)
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(s.scrub(v.Index(i), paths))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			name := fmt.Sprint(key.Interface())
+			var childPaths [][]string
+			removed := s.removedAnywhere(key)
+			for _, path := range paths {
+				if path[0] != name {
+					continue
+				}
+				if len(path) == 1 {
+					removed = true
+				} else {
+					childPaths = append(childPaths, path[1:])
+				}
+			}
+			if !removed {
+				out.SetMapIndex(key, s.scrub(iter.Value(), childPaths))
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// removedAnywhere reports whether the map key is removed at every depth.
+func (s *keyScrubber) removedAnywhere(key reflect.Value) bool {
+	for _, k := range s.anywhere {
+		if isEqual(key.Interface(), k, equalOptions{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// EqualIgnoringKeys performs a deep compare of the provided objects like
+// Equal, after removing the provided keys from the maps nested in both of
+// them, at any depth, including in slices. String keys containing dots are
+// paths of keys from the root instead, such as "meta.requestId". The
+// objects are not modified. This is useful for API responses decoded into
+// maps, which contain timestamps or request IDs:
+//
+//	is.EqualIgnoringKeys(resp, expected, "createdAt", "meta.requestId")
+func (is *Is) EqualIgnoringKeys(actual interface{}, expected interface{}, keys ...interface{}) bool {
+	is.TB.Helper()
+	s := &keyScrubber{}
+	var paths [][]string
+	for _, key := range keys {
+		if str, ok := key.(string); ok && strings.Contains(str, ".") {
+			paths = append(paths, strings.Split(str, "."))
+			continue
+		}
+		s.anywhere = append(s.anywhere, key)
+	}
+	scrub := func(o interface{}) interface{} {
+		if o == nil {
+			return nil
+		}
+		return s.scrub(reflect.ValueOf(o), paths).Interface()
+	}
+	a, e := scrub(actual), scrub(expected)
+	equal, c := compareObjects(a, e, is.equalOpts, is.formatOpts)
+	if !equal {
+		format, args := is.notEqualMessage(a, e, c)
+		is.withComparison(a, e, c).fail(format+" (ignoring keys %v)", append(args, keys)...)
+		return false
+	}
+	return passed(is)
+}
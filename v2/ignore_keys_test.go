@@ -0,0 +1,42 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEqualIgnoringKeys(t *testing.T) {
+	is := New(t)
+
+	actual := map[string]interface{}{
+		"id":        1,
+		"createdAt": "2024-01-02",
+		"items": []interface{}{
+			map[string]interface{}{"name": "a", "createdAt": "x"},
+		},
+		"meta": map[string]interface{}{"requestId": "r1", "page": 1},
+	}
+	expected := map[string]interface{}{
+		"id": 1,
+		"items": []interface{}{
+			map[string]interface{}{"name": "a"},
+		},
+		"meta": map[string]interface{}{"page": 1},
+	}
+	is.EqualIgnoringKeys(actual, expected, "createdAt", "meta.requestId")
+	is.Equal(len(actual), 4)
+	is.EqualIgnoringKeys(map[int]string{1: "a", 2: "b"}, map[int]string{1: "a"}, 2)
+	is.EqualIgnoringKeys(nil, nil)
+
+	hit := 0
+	var msgs []string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	})
+	is.EqualIgnoringKeys(actual, expected, "createdAt")
+	is.EqualIgnoringKeys(actual, expected, "requestId.page")
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+	is.Equal(msgs[0], `objects of type 'map[string]interface {}' differ at ["meta"]["requestId"]: got "r1", want no such key (ignoring keys [createdAt])`)
+}
@@ -0,0 +1,84 @@
+package is
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+)
+
+// diffColor marks the differing pixels in the diff images of ImagesEqual.
+var diffColor = color.RGBA{R: 255, A: 255}
+
+// ImagesEqual compares the pixels of the provided images, and fails if
+// their sizes differ or if more than maxDiffPercent percent of their
+// pixels differ. On failure, a diff image showing the differing pixels in
+// red over a faded copy of expected is written to the temporary directory
+// of the test, and its path is printed.
+func (is *Is) ImagesEqual(actual image.Image, expected image.Image, maxDiffPercent float64) bool {
+	is.TB.Helper()
+	ab, eb := actual.Bounds(), expected.Bounds()
+	if ab.Dx() != eb.Dx() || ab.Dy() != eb.Dy() {
+		is.fail("got image of size %dx%d. expected %dx%d", ab.Dx(), ab.Dy(), eb.Dx(), eb.Dy())
+		return false
+	}
+	diff := image.NewRGBA(image.Rect(0, 0, eb.Dx(), eb.Dy()))
+	count := 0
+	for y := 0; y < eb.Dy(); y++ {
+		for x := 0; x < eb.Dx(); x++ {
+			ac := actual.At(ab.Min.X+x, ab.Min.Y+y)
+			ec := expected.At(eb.Min.X+x, eb.Min.Y+y)
+			if colorsEqual(ac, ec) {
+				gray := color.GrayModel.Convert(ec).(color.Gray)
+				gray.Y = 192 + gray.Y/4
+				diff.Set(x, y, gray)
+				continue
+			}
+			count++
+			diff.Set(x, y, diffColor)
+		}
+	}
+	total := eb.Dx() * eb.Dy()
+	percent := 0.0
+	if total > 0 {
+		percent = float64(count) * 100 / float64(total)
+	}
+	if percent <= maxDiffPercent {
+		return passed(is)
+	}
+	path, err := is.writeDiffImage(diff)
+	if err != nil {
+		is.fail("images differ in %d of %d pixels (%.2f%%), more than %.2f%%, and the diff image could not be written: %v",
+			count, total, percent, maxDiffPercent, err)
+		return false
+	}
+	is.fail("images differ in %d of %d pixels (%.2f%%), more than %.2f%%, diff image written to %s",
+		count, total, percent, maxDiffPercent, path)
+	return false
+}
+
+// colorsEqual reports whether a and b are the same color, regardless of
+// their color models.
+func colorsEqual(a color.Color, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+// writeDiffImage writes img as a PNG file to the temporary directory of the
+// test, and returns its path.
+func (is *Is) writeDiffImage(img image.Image) (string, error) {
+	dir := os.TempDir()
+	if _, ok := is.TB.(*handlerTB); !ok {
+		dir = is.TB.TempDir()
+	}
+	f, err := os.CreateTemp(dir, "image-diff-*.png")
+	if err != nil {
+		return "", err
+	}
+	err = png.Encode(f, img)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return f.Name(), err
+}
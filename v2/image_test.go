@@ -0,0 +1,61 @@
+package is
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestImagesEqual(t *testing.T) {
+	is := New(t)
+
+	newImage := func(w, h int) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				img.Set(x, y, color.White)
+			}
+		}
+		return img
+	}
+	expected := newImage(10, 10)
+	actual := newImage(10, 10)
+	is.ImagesEqual(actual, expected, 0)
+	gray := image.NewGray(image.Rect(5, 5, 15, 15))
+	for i := range gray.Pix {
+		gray.Pix[i] = 255
+	}
+	is.ImagesEqual(gray, expected, 0)
+	actual.Set(3, 4, color.Black)
+	is.ImagesEqual(actual, expected, 1)
+
+	hit := 0
+	var msgs []string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	})
+	is.ImagesEqual(newImage(10, 5), expected, 100)
+	actual.Set(5, 6, color.Black)
+	is.ImagesEqual(actual, expected, 1)
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+	is.Equal(msgs[0], "got image of size 10x5. expected 10x10")
+	m := regexp.MustCompile(`^images differ in 2 of 100 pixels \(2\.00%\), more than 1\.00%, diff image written to (.+\.png)$`).
+		FindStringSubmatch(msgs[1])
+	is.Len(m, 2)
+
+	f, err := os.Open(m[1])
+	is.NotErr(err)
+	defer f.Close()
+	diff, err := png.Decode(f)
+	is.NotErr(err)
+	is.Equal(diff.Bounds(), expected.Bounds())
+	is.True(colorsEqual(diff.At(3, 4), diffColor))
+	is.True(colorsEqual(diff.At(5, 6), diffColor))
+	is.False(colorsEqual(diff.At(0, 0), diffColor))
+}
@@ -0,0 +1,151 @@
+package is
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// AssertionResult is the outcome of an assertion, as passed to
+// interceptors.
+type AssertionResult struct {
+	// Assertion is the name of the assertion, such as "Equal".
+	Assertion string
+	// Caller is the file and line of the assertion in the test.
+	Caller string
+	// Failure is the failure of the assertion, or nil if it passed.
+	Failure *Failure
+}
+
+// Passed reports whether the assertion passed.
+func (r AssertionResult) Passed() bool {
+	return r.Failure == nil
+}
+
+// AssertFunc handles the outcome of an assertion made with is.
+type AssertFunc func(is *Is, r AssertionResult)
+
+// Interceptor wraps the handling of the outcome of every assertion, for
+// cross-cutting behavior such as metrics, screenshots on failure or tracing
+// spans. It returns an AssertFunc which usually calls next, possibly with a
+// modified Is or result: calling next with is.Lax() turns a fatal failure
+// into a non-fatal one, and not calling it drops the outcome.
+//
+// The returned function should call is.TB.Helper(), so that failures are
+// reported at the line of the assertion. In strict mode, next does not
+// return after a failure, as the test is stopped.
+type Interceptor func(next AssertFunc) AssertFunc
+
+// Intercept returns a copy of this Is which passes the outcome of every
+// assertion through the provided interceptors, in addition to those
+// already added. The first interceptor is the outermost one.
+//
+// For example, counting the assertions of a test:
+//
+//	n := 0
+//	is = is.Intercept(func(next is.AssertFunc) is.AssertFunc {
+//		return func(i *is.Is, r is.AssertionResult) {
+//			i.TB.Helper()
+//			n++
+//			next(i, r)
+//		}
+//	})
+func (is *Is) Intercept(interceptors ...Interceptor) *Is {
+	newIs := *is
+	newIs.interceptors = append(append([]Interceptor(nil), is.interceptors...), interceptors...)
+	return &newIs
+}
+
+// ExpectFail runs fn and fails if none of the assertions made with the Is
+// passed to it failed. The failures inside fn are expected, so they are not
+// reported, and fn keeps running after them. This is for testing custom
+// assertions and fixtures:
+//
+//	is.ExpectFail(func(is *is.Is) {
+//		assertValidUser(is, User{})
+//	})
+func (is *Is) ExpectFail(fn func(is *Is)) bool {
+	is.TB.Helper()
+	var failed int32
+	fn(is.Lax().Intercept(func(next AssertFunc) AssertFunc {
+		return func(is *Is, r AssertionResult) {
+			is.TB.Helper()
+			if r.Failure != nil {
+				atomic.AddInt32(&failed, 1)
+				return
+			}
+			next(is, r)
+		}
+	}))
+	if atomic.LoadInt32(&failed) == 0 {
+		is.fail("expected at least one assertion to fail")
+		return false
+	}
+	return passed(is)
+}
+
+// passed reports a passing assertion and returns true. Assertions return
+// it on success, as they call fail on failure.
+func passed(is *Is) bool {
+	var r AssertionResult
+	if is.tap != nil || is.budget != nil || len(is.interceptors) > 0 {
+		name, frame := assertionFrame()
+		r.Assertion = name
+		if frame.File != "" {
+			r.Caller = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+	}
+	is.dispatch(r)
+	return true
+}
+
+// dispatch passes the outcome of an assertion through the interceptors,
+// and records it. Every assertion ends up here, through passed or fail.
+func (is *Is) dispatch(r AssertionResult) {
+	is.TB.Helper()
+	next := record
+	for i := len(is.interceptors) - 1; i >= 0; i-- {
+		next = is.interceptors[i](next)
+	}
+	next(is, r)
+}
+
+// record records the outcome of an assertion, and reports failures to the
+// testing object.
+func record(is *Is, r AssertionResult) {
+	is.TB.Helper()
+	countAssertion(is)
+	if is.budget != nil {
+		is.budget.mark(r.Assertion, r.Caller)
+	}
+	if r.Failure == nil {
+		if is.tap != nil {
+			is.tap.report(is, r.Assertion, true, "")
+		}
+		return
+	}
+	f := *r.Failure
+	is.addFailure(f)
+	if is.tap != nil {
+		is.tap.report(is, r.Assertion, false, f.Message)
+	}
+	if is.report != nil {
+		is.report.add(is, f)
+	}
+	switch {
+	case f.Warning:
+		is.TB.Logf("warning: %s", f.Message)
+	case is.strict || is.laxLimit.reached():
+		msg := f.Message
+		if held := takeBuffered(is.TB); held != "" {
+			msg = held + "\n" + bufferedMessage(f)
+		}
+		if !is.strict {
+			msg += fmt.Sprintf("\nstopping after %d failures", is.laxLimit.max)
+		}
+		is.TB.Fatalf("%s", msg)
+	case is.bufferOutput && bufferFailure(is.TB, f):
+		is.TB.Fail()
+	default:
+		is.TB.Errorf("%s", f.Message)
+	}
+}
@@ -0,0 +1,82 @@
+package is
+
+import (
+	"testing"
+)
+
+func TestIntercept(t *testing.T) {
+	is := New(t)
+
+	var trace []string
+	tracing := func(label string) Interceptor {
+		return func(next AssertFunc) AssertFunc {
+			return func(is *Is, r AssertionResult) {
+				is.TB.Helper()
+				trace = append(trace, label+":"+r.Assertion)
+				next(is, r)
+			}
+		}
+	}
+	annotate := func(next AssertFunc) AssertFunc {
+		return func(is *Is, r AssertionResult) {
+			is.TB.Helper()
+			if !r.Passed() {
+				f := *r.Failure
+				f.Message += " (see screenshot.png)"
+				r.Failure = &f
+			}
+			next(is, r)
+		}
+	}
+
+	c := NewCollector()
+	intercepted := c.Intercept(tracing("outer")).Intercept(tracing("inner"), annotate)
+	intercepted.Equal(1, 1)
+	intercepted.True(false)
+	c.True(true)
+	is.Equal(trace, []string{"outer:Equal", "inner:Equal", "outer:True", "inner:True"})
+	is.Len(c.Errs(), 1)
+	is.ErrMsg(c.Errs()[0], "[True] expected boolean to be true (see screenshot.png)")
+	is.Equal(c.Failures()[0].Message, "[True] expected boolean to be true (see screenshot.png)")
+	is.Matches(c.Failures()[0].Caller, `/intercept_test\.go:\d+$`)
+
+	dropFailures := func(next AssertFunc) AssertFunc {
+		return func(is *Is, r AssertionResult) {
+			is.TB.Helper()
+			if r.Passed() {
+				next(is, r)
+			}
+		}
+	}
+	is.Intercept(dropFailures).Equal(1, 2)
+
+	lax := func(next AssertFunc) AssertFunc {
+		return func(is *Is, r AssertionResult) {
+			is.TB.Helper()
+			next(is.Lax(), r)
+		}
+	}
+	tb := &cleanupTB{}
+	New(tb).Intercept(lax).True(false)
+	is.Equal(tb.errors, []string{"[True] expected boolean to be true"})
+}
+
+func TestExpectFail(t *testing.T) {
+	is := New(t)
+
+	is.ExpectFail(func(is *Is) {
+		is.Equal(1, 2)
+		is.True(false)
+	})
+
+	c := NewCollector()
+	is.True(c.Strict().ExpectFail(func(is *Is) {
+		is.Equal(1, 1)
+		is.Equal(1, 2)
+	}))
+	is.False(c.ExpectFail(func(is *Is) {
+		is.Equal(1, 1)
+	}))
+	is.ErrMsg(c.Err(), "[ExpectFail] expected at least one assertion to fail")
+	is.Len(c.Failures(), 1)
+}
@@ -3,6 +3,9 @@ package is
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -20,12 +23,36 @@ type Equaler interface {
 // in the Go test framework. The methods provided allow for a more natural,
 // efficient and expressive approach to writing tests. The goal is to write
 // fewer lines of code while improving communication of intent.
+//
+// An Is is immutable once created: the methods configuring it return a
+// modified copy, so an instance can be shared by tests and subtests, and
+// derived instances never affect each other.
 type Is struct {
-	TB         testing.TB
-	strict     bool
-	failFormat string
-	failArgs   []interface{}
-	msgSep     string
+	TB           testing.TB
+	strict       bool
+	msgs         []msgPart
+	msgSep       string
+	equalOpts    equalOptions
+	formatOpts   formatOptions
+	tap          *TAPWriter
+	report       *FailureReport
+	values       *failureValues
+	backoff      Backoff
+	context      []contextValue
+	seed         *int64
+	failures     *failureList
+	group        string
+	counter      *assertionCounter
+	budget       *budgetTracker
+	jsonKeyOrder bool
+	interceptors []Interceptor
+	nameFormat   string
+	noNames      bool
+	verbosity    Verbosity
+	warn         bool
+	failFunc     FailFunc
+	bufferOutput bool
+	laxLimit     *failureLimit
 }
 
 // New creates a new instance of the Is object and stores a reference to the
@@ -34,7 +61,14 @@ func New(tb testing.TB) *Is {
 	if tb == nil {
 		panic("You must provide a testing object.")
 	}
-	return &Is{TB: tb, strict: true}
+	return &Is{
+		TB:        tb,
+		strict:    true,
+		tap:       EnvTAPWriter(),
+		report:    EnvFailureReport(),
+		failures:  &failureList{},
+		verbosity: EnvVerbosity(),
+	}
 }
 
 // New creates a new copy of your Is object and replaces the internal testing
@@ -50,18 +84,117 @@ func New(tb testing.TB) *Is {
 func (is *Is) New(tb testing.TB) *Is {
 	newIs := *is
 	newIs.TB = tb
+	if is.counter != nil {
+		newIs.counter = newAssertionCounter(tb, is.counter.warn)
+	}
+	return &newIs
+}
+
+// Clone returns a copy of this Is which shares no message state with it.
+// The chainable methods, such as Msg, AddMsg, Lax or WithContext, already
+// return copies and never modify their receiver, so derived instances
+// cannot change each other's configuration. Clone is useful to hand a
+// copy to code which keeps it, and makes that guarantee explicit. The
+// copy still reports to the same testing object, TAP writer and failure
+// report, and shares the list returned by Failures.
+func (is *Is) Clone() *Is {
+	newIs := *is
+	newIs.msgs = append([]msgPart(nil), is.msgs...)
+	newIs.context = append([]contextValue(nil), is.context...)
+	newIs.interceptors = append([]Interceptor(nil), is.interceptors...)
 	return &newIs
 }
 
+// msgPart is a failure message added with Msg, AddMsg or PrependMsg.
+type msgPart struct {
+	format string
+	args   []interface{}
+}
+
+// String renders the message. A message without arguments is used
+// verbatim, so that a literal "%" does not produce formatting artifacts.
+func (m msgPart) String() string {
+	if len(m.args) == 0 {
+		return m.format
+	}
+	return fmt.Sprintf(m.format, m.args...)
+}
+
 // Msg defines a message to print in the event of a failure. This allows you
-// to print out additional information about a failure if it happens.
+// to print out additional information about a failure if it happens. The
+// message is formatted like with fmt.Sprintf, unless there are no
+// arguments, in which case it is used verbatim.
 func (is *Is) Msg(format string, args ...interface{}) *Is {
 	newIs := *is
-	newIs.failFormat = format
-	newIs.failArgs = args
+	newIs.msgs = []msgPart{{format: format, args: append([]interface{}(nil), args...)}}
 	return &newIs
 }
 
+// message renders the messages added with Msg, AddMsg and PrependMsg,
+// each formatted independently and joined with the separator.
+func (is *Is) message() string {
+	parts := make([]string, 0, len(is.msgs))
+	for _, m := range is.msgs {
+		if m.format != "" {
+			parts = append(parts, m.String())
+		}
+	}
+	return strings.Join(parts, is.getMsgSep())
+}
+
+// lazyMsg is a failure message argument computed only when it is
+// formatted, which happens when an assertion fails.
+type lazyMsg func() string
+
+func (f lazyMsg) String() string {
+	return f()
+}
+
+// MsgFunc defines a message to print in the event of a failure, like Msg,
+// but the message is computed by calling f only when an assertion fails.
+// This avoids building expensive context, such as request dumps, for
+// assertions that pass. Each failure calls f again.
+func (is *Is) MsgFunc(f func() string) *Is {
+	return is.Msg("%s", lazyMsg(f))
+}
+
+// contextValue is a key/value pair attached to failures with WithContext.
+type contextValue struct {
+	key   string
+	value interface{}
+}
+
+// WithContext attaches a key/value pair, such as a user ID, a request ID or
+// a random seed, to every failure reported by the returned Is. The pairs
+// are rendered one per line after the failure message, in the order they
+// were attached. Attaching a key again replaces its value.
+func (is *Is) WithContext(key string, value interface{}) *Is {
+	newIs := *is
+	newIs.context = make([]contextValue, 0, len(is.context)+1)
+	replaced := false
+	for _, cv := range is.context {
+		if cv.key == key {
+			cv.value = value
+			replaced = true
+		}
+		newIs.context = append(newIs.context, cv)
+	}
+	if !replaced {
+		newIs.context = append(newIs.context, contextValue{key: key, value: value})
+	}
+	return &newIs
+}
+
+// contextBlock renders the pairs attached with WithContext for failure
+// messages.
+func (is *Is) contextBlock() string {
+	var b strings.Builder
+	for _, cv := range is.context {
+		fmt.Fprintf(&b, "\n\t%s: %s", cv.key, is.formatValue(cv.value))
+	}
+	return b.String()
+}
+
 func (is *Is) MsgSep(sep string) *Is {
 	newIs := *is
 	newIs.msgSep = sep
@@ -75,9 +208,35 @@ func (is *Is) getMsgSep() string {
 	return " - "
 }
 
+// defaultNameFormat is the prefix of failure messages naming the failed
+// assertion, unless set with NameFormat.
+const defaultNameFormat = "[%s] "
+
+// NameFormat returns a copy of this instance of Is which prefixes failure
+// messages with the name of the failed assertion formatted with format,
+// such as "%s: ". The default is "[%s] ", which gives messages such as
+// "[Equal] got '1' (int). expected '2' (int)", so that failures in large
+// CI logs can be grouped by assertion. An empty format disables the
+// prefix.
+func (is *Is) NameFormat(format string) *Is {
+	newIs := *is
+	newIs.nameFormat = format
+	newIs.noNames = format == ""
+	return &newIs
+}
+
+func (is *Is) getNameFormat() string {
+	if is.nameFormat != "" {
+		return is.nameFormat
+	}
+	return defaultNameFormat
+}
+
 // AddMsg appends a message to print in the event of a failure. This allows
 // you to build a failure message in multiple steps. If no message was
-// previously set, simply sets the message.
+// previously set, simply sets the message. Each message is formatted
+// independently, like with Msg, so verbs in one of them cannot consume the
+// arguments of another.
 //
 // This method is most useful as a way of setting a default error message,
 // then adding additional information to the output for specific assertions.
@@ -87,23 +246,21 @@ func (is *Is) getMsgSep() string {
 // /*do things*/
 // is.AddMsg("Raw Response: %s",body).Equal(res.StatusCode, http.StatusCreated)
 func (is *Is) AddMsg(format string, args ...interface{}) *Is {
-	if is.failFormat == "" {
-		return is.Msg(format, args...)
-	}
 	newIs := *is
-	newIs.failFormat = fmt.Sprintf("%s%s%s", is.failFormat, is.getMsgSep(), format)
-	newIs.failArgs = append(is.failArgs, args...)
+	newIs.msgs = append(append([]msgPart(nil), is.msgs...), msgPart{
+		format: format,
+		args:   append([]interface{}(nil), args...),
+	})
 	return &newIs
 }
 
 // PrependMsg is like AddMsg, but it adds the message before currently added messages
 func (is *Is) PrependMsg(format string, args ...interface{}) *Is {
-	if is.failFormat == "" {
-		return is.Msg(format, args...)
-	}
 	newIs := *is
-	newIs.failFormat = fmt.Sprintf("%s%s%s", format, is.getMsgSep(), is.failFormat)
-	newIs.failArgs = append(args, is.failArgs...)
+	newIs.msgs = append([]msgPart{{
+		format: format,
+		args:   append([]interface{}(nil), args...),
+	}}, is.msgs...)
 	return &newIs
 }
 
@@ -116,6 +273,45 @@ func (is *Is) Lax() *Is {
 	return &newIs
 }
 
+// LaxLimit returns a lax copy of this instance of Is, see Lax, which aborts
+// the test at the n-th failure of the assertions made with it and the
+// copies made from it. This keeps a single broken fixture from producing
+// thousands of cascading failures. A limit of 0 or less means no limit.
+func (is *Is) LaxLimit(n int) *Is {
+	newIs := *is
+	newIs.strict = false
+	newIs.laxLimit = nil
+	if n > 0 {
+		newIs.laxLimit = &failureLimit{max: int64(n)}
+	}
+	return &newIs
+}
+
+// failureLimit counts the failures of the copies of an Is made with
+// LaxLimit.
+type failureLimit struct {
+	max int64
+	n   int64
+}
+
+// reached counts a failure and reports whether it is the last one allowed.
+func (l *failureLimit) reached() bool {
+	return l != nil && atomic.AddInt64(&l.n, 1) >= l.max
+}
+
+// Warn returns a copy of this instance of Is whose failures are logged with
+// the Log method of the testing object, instead of failing the test. They
+// are still passed to interceptors, failure reports and TAP output, marked
+// as warnings. Use this for soft expectations, such as performance hints or
+// deprecation checks, which should be visible without blocking anything:
+//
+//	is.Warn().WithinBudget(10*time.Millisecond, render)
+func (is *Is) Warn() *Is {
+	newIs := *is
+	newIs.warn = true
+	return &newIs
+}
+
 // Strict returns a copy of this instance of Is which aborts the test if a
 // failure occurs. This is the default behavior, thus this method has no
 // effect unless it is used to reverse a previous call to Lax.
@@ -133,13 +329,121 @@ func (is *Is) Strict() *Is {
 // the same type.
 func (is *Is) Equal(actual interface{}, expected interface{}) bool {
 	is.TB.Helper()
-	if !isEqual(actual, expected) {
-		fail(is, "got '%v' (%s). expected '%v' (%s)",
-			actual, objectTypeName(actual),
-			expected, objectTypeName(expected))
+	equal, c := compareObjects(actual, expected, is.equalOpts, is.formatOpts)
+	if !equal {
+		format, args := is.notEqualMessage(actual, expected, c)
+		is.withComparison(actual, expected, c).fail(format, args...)
+		return false
+	}
+	return passed(is)
+}
+
+// notEqualMessage returns the failure message of Equal for objects found
+// different by the comparer c.
+func (is *Is) notEqualMessage(actual interface{}, expected interface{}, c *comparer) (string, []interface{}) {
+	if is.formatOpts.noDiff {
+		if c.nested() {
+			return "objects of type '%s' differ at %s (%d places)", []interface{}{
+				objectTypeName(actual), c.diffPath, c.diffCount,
+			}
+		}
+		return "got %s, expected %s", []interface{}{valueSummary(actual), valueSummary(expected)}
+	}
+	if c.nested() {
+		return "objects of type '%s' differ at %s%s", []interface{}{
+			objectTypeName(actual), c.mismatch(), c.cycleDetails(),
+		}
+	}
+	return "got '%s' (%s). expected '%s' (%s)%s", []interface{}{
+		is.formatValue(actual), objectTypeName(actual),
+		is.formatValue(expected), objectTypeName(expected),
+		c.details(),
+	}
+}
+
+// withComparison returns a copy of this Is carrying the actual and expected
+// values found different by the comparer c, unless NoDiff is set.
+func (is *Is) withComparison(actual interface{}, expected interface{}, c *comparer) *Is {
+	if is.formatOpts.noDiff {
+		return is
+	}
+	failIs := is.withValues(actual, expected)
+	if c.nested() {
+		failIs.values.diff = c.mismatch()
+	}
+	return failIs
+}
+
+// valueSummary returns the type of o, with its length if it has one.
+func valueSummary(o interface{}) string {
+	v := reflect.ValueOf(o)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return fmt.Sprintf("%s of length %d", objectTypeName(o), v.Len())
+	}
+	return objectTypeName(o)
+}
+
+// EqualExported is like Equal, but it only compares exported struct fields,
+// see IgnoreUnexported.
+func (is *Is) EqualExported(actual interface{}, expected interface{}) bool {
+	is.TB.Helper()
+	return is.IgnoreUnexported().Equal(actual, expected)
+}
+
+// EqualNormalized is like Equal, but it passes both objects through
+// normalize before comparing them, for example to sort slices or to zero
+// timestamps. Failure messages show the normalized objects.
+func (is *Is) EqualNormalized(actual interface{}, expected interface{}, normalize func(interface{}) interface{}) bool {
+	is.TB.Helper()
+	return is.equalAfter(normalize(actual), normalize(expected), "normalization")
+}
+
+// EqualSorted is like Equal, but it sorts copies of the provided slices or
+// arrays with less before comparing them, for results whose order is not
+// specified, such as those collected from maps or goroutines. Failure
+// messages show the sorted copies. See EqualSortedT for a generic variant.
+func (is *Is) EqualSorted(actual interface{}, expected interface{}, less func(a, b interface{}) bool) bool {
+	is.TB.Helper()
+	a, ok := sortedCopy(actual, less)
+	if !ok {
+		is.fail("expected a slice or an array, got '%s'", objectTypeName(actual))
+		return false
+	}
+	e, ok := sortedCopy(expected, less)
+	if !ok {
+		is.fail("expected a slice or an array, got '%s'", objectTypeName(expected))
+		return false
+	}
+	return is.equalAfter(a, e, "sorting")
+}
+
+// sortedCopy returns a copy of the slice or array o, as a slice, sorted
+// with less.
+func sortedCopy(o interface{}, less func(a, b interface{}) bool) (interface{}, bool) {
+	v := reflect.ValueOf(o)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	cp := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+	reflect.Copy(cp, v)
+	sort.SliceStable(cp.Interface(), func(i, j int) bool {
+		return less(cp.Index(i).Interface(), cp.Index(j).Interface())
+	})
+	return cp.Interface(), true
+}
+
+// equalAfter is like Equal, for objects transformed by step, such as
+// "sorting", which failure messages mention.
+func (is *Is) equalAfter(actual interface{}, expected interface{}, step string) bool {
+	is.TB.Helper()
+	equal, c := compareObjects(actual, expected, is.equalOpts, is.formatOpts)
+	if !equal {
+		format, args := is.notEqualMessage(actual, expected, c)
+		is.withComparison(actual, expected, c).fail(format+" (after %s)", append(args, step)...)
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // NotEqual performs a deep compare of the provided objects and fails if they are
@@ -150,13 +454,13 @@ func (is *Is) Equal(actual interface{}, expected interface{}) bool {
 // the same type.
 func (is *Is) NotEqual(a interface{}, b interface{}) bool {
 	is.TB.Helper()
-	if isEqual(a, b) {
-		fail(is, "expected objects '%s' and '%s' not to be equal",
+	if isEqual(a, b, is.equalOpts) {
+		is.fail("expected objects '%s' and '%s' not to be equal",
 			objectTypeName(a),
 			objectTypeName(b))
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // OneOf performs a deep compare of the provided object and an array of
@@ -165,23 +469,27 @@ func (is *Is) NotEqual(a interface{}, b interface{}) bool {
 //
 // OneOf does not respect type differences. If the types are different and
 // comparable (eg int32 and int64), they will be compared as though they are
-// the same type.
+// the same type. If a comparison object implements Equaler, its Equal method
+// is used as well.
+//
+// On failure, each comparison object is listed and the one closest to the
+// provided object is marked.
 func (is *Is) OneOf(a interface{}, b ...interface{}) bool {
 	is.TB.Helper()
-	result := false
-	for _, o := range b {
-		result = isEqual(a, o)
-		if result {
-			break
+	if indexOfEqual(a, b, is.equalOpts) < 0 {
+		closest := indexOfClosest(a, b, is.equalOpts)
+		var list strings.Builder
+		for i, o := range b {
+			fmt.Fprintf(&list, "\n\t[%d] %s (%s)", i, is.formatValue(o), objectTypeName(o))
+			if i == closest {
+				list.WriteString(" <- closest")
+			}
 		}
-	}
-	if !result {
-		fail(is, "expected object '%s' to be equal to one of '%s', but got: %v and %v",
-			objectTypeName(a),
-			objectTypeNames(b), a, b)
+		is.fail("expected object '%s' to be equal to one of %d candidates, but got: %s%s",
+			objectTypeName(a), len(b), is.formatValue(a), list.String())
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // NotOneOf performs a deep compare of the provided object and an array of
@@ -190,43 +498,36 @@ func (is *Is) OneOf(a interface{}, b ...interface{}) bool {
 //
 // NotOneOf does not respect type differences. If the types are different and
 // comparable (eg int32 and int64), they will be compared as though they are
-// the same type.
+// the same type. If a comparison object implements Equaler, its Equal method
+// is used as well.
 func (is *Is) NotOneOf(a interface{}, b ...interface{}) bool {
 	is.TB.Helper()
-	result := false
-	for _, o := range b {
-		result = isEqual(a, o)
-		if result {
-			break
-		}
-	}
-	if result {
-		fail(is, "expected object '%s' not to be equal to one of '%s', but got: %v and %v",
-			objectTypeName(a),
-			objectTypeNames(b), a, b)
+	if i := indexOfEqual(a, b, is.equalOpts); i >= 0 {
+		is.fail("expected object '%s' not to be equal to one of '%s', but it is equal to candidate [%d]: %s",
+			objectTypeName(a), objectTypeNames(b), i, is.formatValue(b[i]))
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // Err checks the provided error object to determine if an error is present.
 func (is *Is) Err(e error) bool {
 	is.TB.Helper()
 	if isNil(e) {
-		fail(is, "expected error")
+		is.fail("expected error%s", typedNilDetails(e))
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // ErrMsg checks the provided error object to determine if error message matches the expected string
-func (is *Is) ErrMsg(e error, expectedMsg string) {
+func (is *Is) ErrMsg(e error, expectedMsg string) bool {
 	is.TB.Helper()
 	if isNil(e) {
-		fail(is, "expected error %#v", expectedMsg)
-	} else {
-		is.Equal(e.Error(), expectedMsg)
+		is.fail("expected error %#v", expectedMsg)
+		return false
 	}
+	return is.Equal(e.Error(), expectedMsg)
 }
 
 // NotErr checks the provided error object to determine if an error is not
@@ -234,50 +535,94 @@ func (is *Is) ErrMsg(e error, expectedMsg string) {
 func (is *Is) NotErr(e error) bool {
 	is.TB.Helper()
 	if !isNil(e) {
-		fail(is, "expected no error, but got: %v", e)
+		is.fail("expected no error, but got: %v", e)
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // Nil checks the provided object to determine if it is nil.
 func (is *Is) Nil(o interface{}) bool {
 	is.TB.Helper()
 	if !isNil(o) {
-		fail(is, "expected object '%s' to be nil, but got: %v", objectTypeName(o), o)
+		is.fail("expected object '%s' to be nil, but got: %s", objectTypeName(o), is.formatValue(o))
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // NotNil checks the provided object to determine if it is not nil.
 func (is *Is) NotNil(o interface{}) bool {
 	is.TB.Helper()
 	if isNil(o) {
-		fail(is, "expected object '%s' not to be nil", objectTypeName(o))
+		is.fail("expected object '%s' not to be nil", objectTypeName(o))
+		return false
+	}
+	return passed(is)
+}
+
+// NilStrict checks the provided object to determine if it is a nil
+// interface. Unlike Nil, it fails for a non-nil interface containing a
+// typed nil, such as an error holding a nil *MyErr, which compares as
+// non-nil with == nil.
+func (is *Is) NilStrict(o interface{}) bool {
+	is.TB.Helper()
+	if o != nil {
+		if isNil(o) {
+			is.fail("expected nil, but got a non-nil interface containing typed nil %s", typedNilString(o))
+		} else {
+			is.fail("expected nil, but got: %s (%s)", is.formatValue(o), objectTypeName(o))
+		}
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // True checks the provided boolean to determine if it is true.
 func (is *Is) True(b bool) bool {
 	is.TB.Helper()
 	if !b {
-		fail(is, "expected boolean to be true")
+		is.fail("expected boolean to be true")
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // False checks the provided boolean to determine if is false.
 func (is *Is) False(b bool) bool {
 	is.TB.Helper()
 	if b {
-		fail(is, "expected boolean to be false")
+		is.fail("expected boolean to be false")
 		return false
 	}
-	return true
+	return passed(is)
+}
+
+// Condition calls the provided predicate and fails if it returns false,
+// with a message including the name of the condition, such as
+// "condition 'cache warmed' not met". This makes checks which would use
+// True self-documenting.
+func (is *Is) Condition(name string, pred func() bool) bool {
+	is.TB.Helper()
+	if !pred() {
+		is.fail("condition '%s' not met", name)
+		return false
+	}
+	return passed(is)
+}
+
+// Check fails with the provided message if ok is false, formatted with args
+// as with fmt.Sprintf. Like any assertion, it goes through Msg, the
+// interceptors, the failure reports and the assertion budget, so it is the
+// building block for assertions declared in other packages, such as
+// slogtest.
+func (is *Is) Check(ok bool, format string, args ...interface{}) bool {
+	is.TB.Helper()
+	if !ok {
+		is.fail(format, args...)
+		return false
+	}
+	return passed(is)
 }
 
 // Zero checks the provided object to determine if it is the zero value
@@ -293,10 +638,10 @@ func (is *Is) False(b bool) bool {
 func (is *Is) Zero(o interface{}) bool {
 	is.TB.Helper()
 	if !isZero(o) {
-		fail(is, "expected object '%s' to be zero value, but it was: %v", objectTypeName(o), o)
+		is.fail("expected object '%s' to be zero value, but it was: %s", objectTypeName(o), is.formatValue(o))
 		return false
 	}
-	return true
+	return passed(is)
 }
 
 // NotZero checks the provided object to determine if it is not the zero
@@ -312,74 +657,269 @@ func (is *Is) Zero(o interface{}) bool {
 func (is *Is) NotZero(o interface{}) bool {
 	is.TB.Helper()
 	if isZero(o) {
-		fail(is, "expected object '%s' not to be zero value", objectTypeName(o))
+		is.fail("expected object '%s' not to be zero value", objectTypeName(o))
 		return false
 	}
-	return true
+	return passed(is)
+}
+
+// Empty checks the provided object to determine if it is empty.
+//
+// Strings, arrays, slices, maps and channels are empty if their length is 0,
+// which includes nil slices, maps and channels. Pointers are empty if they
+// are nil or point to an empty object. Any other object is empty if it is the
+// zero value for its type.
+func (is *Is) Empty(o interface{}) bool {
+	is.TB.Helper()
+	if !isEmpty(o) {
+		if l, ok := objectLen(o); ok {
+			is.fail("expected object '%s' to be empty, but it has length %d: %s", objectTypeName(o), l, is.formatValue(o))
+		} else {
+			is.fail("expected object '%s' to be empty, but got: %s", objectTypeName(o), is.formatValue(o))
+		}
+		return false
+	}
+	return passed(is)
+}
+
+// NotEmpty checks the provided object to determine if it is not empty. See
+// Empty for the definition of an empty object.
+func (is *Is) NotEmpty(o interface{}) bool {
+	is.TB.Helper()
+	if isEmpty(o) {
+		is.fail("expected object '%s' not to be empty", objectTypeName(o))
+		return false
+	}
+	return passed(is)
 }
 
 // Len checks the provided object to determine if it is the same length as the
 // provided length argument.
 //
-// If the object is not one of type array, slice or map, it will fail.
+// If the object is not one of type string, array, slice, map or chan, it will
+// fail. A nil slice, map or chan has length 0.
 func (is *Is) Len(o interface{}, l int) bool {
 	is.TB.Helper()
-	t := reflect.TypeOf(o)
-	if o == nil ||
-		(t.Kind() != reflect.Array &&
-			t.Kind() != reflect.Slice &&
-			t.Kind() != reflect.Map) {
-		fail(is, "expected object '%s' to be of length '%d', but the object is not one of array, slice or map", objectTypeName(o), l)
+	rLen, ok := objectLen(o)
+	if !ok {
+		is.fail("expected object '%s' to be of length '%d', but the object is not one of string, array, slice, map or chan", objectTypeName(o), l)
 		return false
 	}
-
-	rLen := reflect.ValueOf(o).Len()
 	if rLen != l {
-		fail(is, "expected object '%s' to be of length '%d' but it was: %d", objectTypeName(o), l, rLen)
+		is.fail("expected object '%s' to be of length '%d' but it was: %d", objectTypeName(o), l, rLen)
+		return false
+	}
+	return passed(is)
+}
+
+// LenGreater checks the provided object to determine if its length is greater
+// than the provided length argument. This is useful for collections whose
+// exact size is not deterministic.
+//
+// If the object is not one of type string, array, slice, map or chan, it will
+// fail.
+func (is *Is) LenGreater(o interface{}, l int) bool {
+	is.TB.Helper()
+	rLen, ok := objectLen(o)
+	if !ok {
+		is.fail("expected object '%s' to be longer than '%d', but the object is not one of string, array, slice, map or chan", objectTypeName(o), l)
+		return false
+	}
+	if rLen <= l {
+		is.fail("expected object '%s' to be longer than '%d' but its length was: %d", objectTypeName(o), l, rLen)
 		return false
 	}
-	return true
+	return passed(is)
+}
+
+// LenLess checks the provided object to determine if its length is less than
+// the provided length argument.
+//
+// If the object is not one of type string, array, slice, map or chan, it will
+// fail.
+func (is *Is) LenLess(o interface{}, l int) bool {
+	is.TB.Helper()
+	rLen, ok := objectLen(o)
+	if !ok {
+		is.fail("expected object '%s' to be shorter than '%d', but the object is not one of string, array, slice, map or chan", objectTypeName(o), l)
+		return false
+	}
+	if rLen >= l {
+		is.fail("expected object '%s' to be shorter than '%d' but its length was: %d", objectTypeName(o), l, rLen)
+		return false
+	}
+	return passed(is)
 }
 
 // ShouldPanic expects the provided function to panic. If the function does
 // not panic, this assertion fails.
-func (is *Is) ShouldPanic(f func()) {
+func (is *Is) ShouldPanic(f func()) (panicked bool) {
 	is.TB.Helper()
-	defer func() {
-		r := recover()
-		if r == nil {
-			fail(is, "expected function to panic")
-		}
+	if _, _, panicked = catchPanic(f); !panicked {
+		is.fail("expected function to panic")
+		return false
+	}
+	return passed(is)
+}
+
+// ShouldPanicValue expects the provided function to panic, and returns the
+// recovered value, for further assertions on it. If the function does not
+// panic, this assertion fails and returns nil.
+func (is *Is) ShouldPanicValue(f func()) interface{} {
+	is.TB.Helper()
+	value, _, panicked := catchPanic(f)
+	if !panicked {
+		is.fail("expected function to panic")
+		return nil
+	}
+	passed(is)
+	return value
+}
+
+// ShouldPanicWith expects the provided function to panic with a value
+// equal to expected, compared like with Equal. If the value differs, the
+// failure message includes it and the stack of the panic.
+func (is *Is) ShouldPanicWith(f func(), expected interface{}) bool {
+	is.TB.Helper()
+	value, stack, panicked := catchPanic(f)
+	if !panicked {
+		is.fail("expected function to panic with %s", is.formatValue(expected))
+		return false
+	}
+	if !isEqual(value, expected, is.equalOpts) {
+		is.withValues(value, expected).fail("expected function to panic with %s (%s), but it panicked with %s (%s) at:\n%s",
+			is.formatValue(expected), objectTypeName(expected),
+			is.formatValue(value), objectTypeName(value), stack)
+		return false
+	}
+	return passed(is)
+}
+
+// ShouldPanicBefore runs the provided function in a new goroutine and
+// expects it to panic before timeout, for code which panics only after
+// asynchronous work, such as waiting on a channel. It returns the recovered
+// value. The assertion fails if the function returns without panicking, or
+// if the timeout is reached first, in which case the goroutine is left
+// running.
+//
+// A panic can only be recovered on the goroutine it happens on, so the
+// panic must happen in f itself, not in a goroutine started by f.
+func (is *Is) ShouldPanicBefore(timeout time.Duration, f func()) interface{} {
+	is.TB.Helper()
+	type result struct {
+		value    interface{}
+		panicked bool
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, _, panicked := catchPanic(f)
+		done <- result{value: value, panicked: panicked}
 	}()
-	f()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		if !r.panicked {
+			is.fail("expected function to panic, but it returned")
+			return nil
+		}
+		passed(is)
+		return r.value
+	case <-timer.C:
+		is.fail("expected function to panic within %v", timeout)
+		return nil
+	}
 }
 
 // EqualType checks the type of the two provided objects and
-// fails if they are not the same.
+// fails if they are not the same. Unlike most assertions, it takes the
+// expected object first. See also the generic Cast.
 func (is *Is) EqualType(expected, actual interface{}) bool {
 	is.TB.Helper()
 	if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
-		fail(is, "expected objects '%s' to be of the same type as object '%s'", objectTypeName(expected), objectTypeName(actual))
+		is.fail("expected objects '%s' to be of the same type as object '%s'", objectTypeName(expected), objectTypeName(actual))
 		return false
 	}
-	return true
+	return passed(is)
+}
+
+// Same checks the provided pointers to determine if they have the same type
+// and point to the same object.
+func (is *Is) Same(actual, expected interface{}) bool {
+	is.TB.Helper()
+	a, e := reflect.ValueOf(actual), reflect.ValueOf(expected)
+	if a.Kind() != reflect.Ptr || e.Kind() != reflect.Ptr {
+		is.fail("expected objects '%s' and '%s' to be pointers", objectTypeName(actual), objectTypeName(expected))
+		return false
+	}
+	if a.Type() != e.Type() || a.Pointer() != e.Pointer() {
+		is.fail("expected %p (%s) to be the same pointer as %p (%s)",
+			actual, objectTypeName(actual), expected, objectTypeName(expected))
+		return false
+	}
+	return passed(is)
 }
 
 // WaitForTrue waits until the provided func returns true. If the timeout is
 // reached before the function returns true, the test will fail.
+//
+// The function is called every 100 milliseconds, unless another strategy is
+// set with Backoff.
 func (is *Is) WaitForTrue(timeout time.Duration, f func() bool) {
 	is.TB.Helper()
-	after := time.After(timeout)
-	for {
-		select {
-		case <-after:
-			fail(is, "function did not return true within the timeout of %v", timeout)
-			return
-		default:
-			if f() {
-				return
-			}
-			time.Sleep(100 * time.Millisecond)
-		}
+	is.Eventually(timeout, 0, f)
+}
+
+// Eventually calls the provided func every interval until it returns true.
+// If the timeout is reached before the function returns true, the test will
+// fail. If interval is 0, the strategy set with Backoff is used.
+func (is *Is) Eventually(timeout time.Duration, interval time.Duration, f func() bool) bool {
+	is.TB.Helper()
+	if !poll(timeout, is.pollBackoff(interval), f) {
+		is.fail("function did not return true within the timeout of %v", timeout)
+		return false
+	}
+	return passed(is)
+}
+
+// EventuallyEqual calls the provided getter every interval until it returns
+// a value equal to expected, compared like with Equal. If the timeout is
+// reached first, the test will fail, reporting the last value returned by
+// the getter and how it differs from expected. If interval is 0, the
+// strategy set with Backoff is used.
+func (is *Is) EventuallyEqual(timeout time.Duration, interval time.Duration, get func() interface{}, expected interface{}) bool {
+	is.TB.Helper()
+	var actual interface{}
+	var c *comparer
+	ok := poll(timeout, is.pollBackoff(interval), func() bool {
+		var equal bool
+		actual = get()
+		equal, c = compareObjects(actual, expected, is.equalOpts, is.formatOpts)
+		return equal
+	})
+	if !ok {
+		format, args := is.notEqualMessage(actual, expected, c)
+		is.withComparison(actual, expected, c).fail("getter did not return the expected value within the timeout of %v, last value: "+format,
+			append([]interface{}{timeout}, args...)...)
+		return false
+	}
+	return passed(is)
+}
+
+// WaitForNoError calls the provided func every 100 milliseconds, unless
+// another strategy is set with Backoff, until it returns nil. If the
+// timeout is reached first, the test will fail, reporting the last error
+// returned by the function.
+func (is *Is) WaitForNoError(timeout time.Duration, f func() error) bool {
+	is.TB.Helper()
+	var err error
+	ok := poll(timeout, is.pollBackoff(0), func() bool {
+		err = f()
+		return err == nil
+	})
+	if !ok {
+		is.fail("function did not return nil within the timeout of %v, last error: %v", timeout, err)
+		return false
 	}
+	return passed(is)
 }
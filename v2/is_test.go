@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
+	"unsafe"
 
 	"testing"
+
+	"github.com/ilius/is/v2/istest"
 )
 
 var numberTypes = []reflect.Type{
@@ -101,10 +105,10 @@ func TestIs(t *testing.T) {
 
 	for i, test := range tests {
 		for _, cType := range test.cTypes {
-			fail = func(is *Is, format string, args ...interface{}) {
+			is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 				fmt.Print(fmt.Sprintf(fmt.Sprintf("(test #%d) - ", i)+format, args...))
 				t.FailNow()
-			}
+			})
 			is.Equal(test.a, reflect.ValueOf(test.b).Convert(cType).Interface())
 		}
 		is.Equal(test.a, test.b)
@@ -112,10 +116,10 @@ func TestIs(t *testing.T) {
 
 	for i, test := range tests {
 		for _, cType := range test.cTypes {
-			fail = func(is *Is, format string, args ...interface{}) {
+			is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 				fmt.Print(fmt.Sprintf(fmt.Sprintf("(test #%d) - ", i)+format, args...))
 				t.FailNow()
-			}
+			})
 			is.NotEqual(test.a, reflect.ValueOf(test.c).Convert(cType).Interface())
 		}
 		is.NotEqual(test.a, test.c)
@@ -123,10 +127,10 @@ func TestIs(t *testing.T) {
 
 	for i, test := range tests {
 		for _, cType := range test.cTypes {
-			fail = func(is *Is, format string, args ...interface{}) {
+			is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 				fmt.Print(fmt.Sprintf(fmt.Sprintf("(test #%d) - ", i)+format, args...))
 				t.FailNow()
-			}
+			})
 			is.Zero(reflect.ValueOf(test.d).Convert(cType).Interface())
 		}
 		is.Zero(test.d)
@@ -134,19 +138,19 @@ func TestIs(t *testing.T) {
 
 	for i, test := range tests {
 		for _, cType := range test.cTypes {
-			fail = func(is *Is, format string, args ...interface{}) {
+			is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 				fmt.Print(fmt.Sprintf(fmt.Sprintf("(test #%d) - ", i)+format, args...))
 				t.FailNow()
-			}
+			})
 			is.NotZero(reflect.ValueOf(test.e).Convert(cType).Interface())
 		}
 		is.NotZero(test.e)
 	}
 
-	fail = func(is *Is, format string, args ...interface{}) {
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 		fmt.Print(fmt.Sprintf(format, args...))
 		t.FailNow()
-	}
+	})
 	is.Nil(nil)
 	is.NotNil(&testStruct{v: 1})
 	is.Err(errors.New("error"))
@@ -156,6 +160,9 @@ func TestIs(t *testing.T) {
 	is.False(false)
 	is.Zero(nil)
 	is.Nil((*testStruct)(nil))
+	is.Nil((func())(nil))
+	is.Nil(unsafe.Pointer(nil))
+	is.NotNil(func() {})
 	is.OneOf(1, 2, 3, 1)
 	is.NotOneOf(1, 2, 3)
 	is.EqualType(1, 2)
@@ -164,20 +171,30 @@ func TestIs(t *testing.T) {
 		[]int{1, 2, 3},
 		[3]int{1, 2, 3},
 		map[int]int{1: 1, 2: 2, 3: 3},
+		"abc",
+		make(chan int, 3),
 	}
+	lens[4].(chan int) <- 1
+	lens[4].(chan int) <- 2
+	lens[4].(chan int) <- 3
 	for _, l := range lens {
 		is.Len(l, 3)
+		is.LenGreater(l, 2)
+		is.LenLess(l, 4)
 	}
+	var nilSlice []int
+	is.Len(nilSlice, 0)
+	is.Len((map[int]int)(nil), 0)
 
-	fail = func(is *Is, format string, args ...interface{}) {}
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {})
 	is.Equal((*testStruct)(nil), &testStruct{})
 	is.Equal(&testStruct{}, (*testStruct)(nil))
 	is.Equal((*testStruct)(nil), (*testStruct)(nil))
 
-	fail = func(is *Is, format string, args ...interface{}) {
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 		fmt.Print(fmt.Sprintf(format, args...))
 		t.FailNow()
-	}
+	})
 	is.ShouldPanic(func() {
 		panic("The sky is falling!")
 	})
@@ -187,60 +204,81 @@ func TestIsMsg(t *testing.T) {
 	is := New(t)
 
 	is = is.Msg("something %s", "else")
-	if is.failFormat != "something %s" {
-		t.Fatal("failFormat not set")
-	}
-	if is.failArgs[0].(string) != "else" {
-		t.Fatal("failArgs not set")
+	if is.message() != "something else" {
+		t.Fatal("Msg not set")
 	}
 
 	is = is.AddMsg("another %s %s", "couple", "things")
-	if is.failFormat != "something %s - another %s %s" {
+	if is.message() != "something else - another couple things" {
 		t.Fatal("AddMsg did not work")
 	}
 	is = is.PrependMsg("#%d message", 1)
-	if is.failFormat != "#%d message - something %s - another %s %s" {
+	if is.message() != "#1 message - something else - another couple things" {
 		t.Fatal("PrependMsg did not work")
 	}
-	if is.failArgs[0].(int) != 1 {
-		t.Fatal("failArgs not set")
-	}
-	if is.failArgs[1].(string) != "else" {
-		t.Fatal("failArgs not set")
-	}
-	if is.failArgs[2].(string) != "couple" {
-		t.Fatal("failArgs not set")
-	}
-	if is.failArgs[3].(string) != "things" {
-		t.Fatal("failArgs not set")
-	}
+}
+
+func TestIsMsgVerbs(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	c.Msg("rate %d%%", 50).AddMsg("100% sure").AddMsg("user %s", "bob").True(false)
+	c.Msg("%s and %s", "a").AddMsg("id %d", 7).True(false)
+	is.ErrMsg(c.Errs()[0], "[True] expected boolean to be true - rate 50% - 100% sure - user bob")
+	is.ErrMsg(c.Errs()[1], "[True] expected boolean to be true - a and %!s(MISSING) - id 7")
+}
+
+func TestIsMsgFunc(t *testing.T) {
+	is := New(t)
+
+	calls := 0
+	lazy := is.Lax().MsgFunc(func() string {
+		calls++
+		return fmt.Sprintf("dump %d", calls)
+	})
+	var msg string
+	lazy = lazy.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		msg = fmt.Sprintf(format, args...) + is.getMsgSep() + is.message()
+	})
+	lazy.Equal(1, 1)
+	lazy.True(true)
+	calls0 := calls
+	lazy.AddMsg("id %d", 7).True(false)
+
+	is.Equal(calls0, 0)
+	is.Equal(calls, 1)
+	is.Equal(msg, "expected boolean to be true - dump 1 - id 7")
+}
+
+func TestIsWithContext(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	scoped := c.WithContext("user", 7).WithContext("request", "r1")
+	scoped.Msg("login").True(false)
+	scoped.WithContext("user", 8).Equal(1, 2)
+	c.True(false)
+
+	errs := c.Errs()
+	is.Len(errs, 3)
+	is.ErrMsg(errs[0], "[True] expected boolean to be true - login\n\tuser: 7\n\trequest: r1")
+	is.ErrMsg(errs[1], "[Equal] got '1' (int). expected '2' (int)\n\tuser: 8\n\trequest: r1")
+	is.ErrMsg(errs[2], "[True] expected boolean to be true")
 }
 
 func TestIsAddMsg(t *testing.T) {
 	is := New(t)
 	is = is.AddMsg("something %s %s", "new", "here")
-	if is.failFormat != "something %s %s" {
-		t.Fatal("AddMsg: bad failFormat")
-	}
-	if is.failArgs[0].(string) != "new" {
-		t.Fatal("AddMsg: bad failArgs[0]")
-	}
-	if is.failArgs[1].(string) != "here" {
-		t.Fatal("AddMsg: bad failArgs[1]")
+	if is.message() != "something new here" {
+		t.Fatal("AddMsg: bad message")
 	}
 }
 
 func TestIsPrependMsg(t *testing.T) {
 	is := New(t)
 	is = is.PrependMsg("something %s %s", "new", "here")
-	if is.failFormat != "something %s %s" {
-		t.Fatal("PrependMsg: bad failFormat")
-	}
-	if is.failArgs[0].(string) != "new" {
-		t.Fatal("PrependMsg: bad failArgs[0]")
-	}
-	if is.failArgs[1].(string) != "here" {
-		t.Fatal("PrependMsg: bad failArgs[1]")
+	if is.message() != "something new here" {
+		t.Fatal("PrependMsg: bad message")
 	}
 }
 
@@ -249,8 +287,8 @@ func TestIsMsgSep(t *testing.T) {
 	is = is.MsgSep(", ")
 	is = is.AddMsg("msg one")
 	is = is.AddMsg("msg two")
-	if is.failFormat != "msg one, msg two" {
-		t.Fatal("bad failFormat")
+	if is.message() != "msg one, msg two" {
+		t.Fatal("bad message")
 	}
 }
 
@@ -259,16 +297,16 @@ func TestIsLax(t *testing.T) {
 
 	hit := 0
 
-	fail = func(is *Is, format string, args ...interface{}) {
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 		if is.strict {
 			t.FailNow()
 		}
 		hit++
-	}
+	})
 
 	is.Lax().Equal(1, 2)
 
-	fail = failDefault
+	is = is.WithFailFunc(nil)
 
 	is.Strict().Equal(hit, 1)
 }
@@ -277,15 +315,15 @@ func TestIsOneOf(t *testing.T) {
 	is := New(t)
 
 	hit := 0
-	fail = func(is *Is, format string, args ...interface{}) {
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 		hit++
-	}
+	})
 	is.OneOf(2, 1, 2, 3)
 	is.OneOf(4, 1, 2, 3)
 	is.NotOneOf(2, 1, 2, 3)
 	is.NotOneOf(4, 1, 2, 3)
 
-	fail = failDefault
+	is = is.WithFailFunc(nil)
 	is.Strict().Equal(hit, 2)
 }
 
@@ -293,9 +331,9 @@ func TestIsFailures(t *testing.T) {
 	is := New(t)
 
 	hit := 0
-	fail = func(is *Is, format string, args ...interface{}) {
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 		hit++
-	}
+	})
 
 	is.NotEqual(1, 1)
 	is.Err(nil)
@@ -309,19 +347,24 @@ func TestIsFailures(t *testing.T) {
 	is.NotZero(0)
 	is.Len([]int{}, 1)
 	is.Len(nil, 1)
+	is.Len(1, 1)
+	is.LenGreater([]int{1}, 1)
+	is.LenGreater(nil, 0)
+	is.LenLess([]int{1}, 1)
+	is.LenLess(nil, 1)
 	is.ShouldPanic(func() {})
 
-	fail = failDefault
-	is.Strict().Equal(hit, 13)
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 18)
 }
 
 func TestWaitForTrue(t *testing.T) {
 	is := New(t)
 
 	hit := 0
-	fail = func(is *Is, format string, args ...interface{}) {
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 		hit++
-	}
+	})
 
 	is.WaitForTrue(200*time.Millisecond, func() bool {
 		return false
@@ -334,6 +377,355 @@ func TestWaitForTrue(t *testing.T) {
 	is.Strict().Equal(hit, 1)
 }
 
+func TestEventually(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+
+	calls := 0
+	ok := is.Eventually(time.Second, time.Millisecond, func() bool {
+		calls++
+		return calls == 3
+	})
+	ok = ok && !is.Eventually(20*time.Millisecond, time.Millisecond, func() bool {
+		return false
+	})
+
+	is = is.WithFailFunc(nil)
+	is.Strict().True(ok)
+	is.Strict().Equal(calls, 3)
+	is.Strict().Equal(hit, 1)
+}
+
+func TestEventuallyEqual(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+
+	calls := 0
+	get := func() interface{} {
+		calls++
+		return []int{calls, 0}
+	}
+	ok := is.EventuallyEqual(time.Second, time.Millisecond, get, []int{3, 0})
+	ok = ok && !is.EventuallyEqual(20*time.Millisecond, time.Millisecond, func() interface{} {
+		return []int{1, 2}
+	}, []int{1, 3})
+
+	is = is.WithFailFunc(nil)
+	is.Strict().True(ok)
+	is.Strict().Equal(calls, 3)
+	is.Strict().Equal(hit, 1)
+	is.Strict().Equal(msg, "getter did not return the expected value within the timeout of 20ms, last value: objects of type '[]int' differ at [1]: got 2, want 3")
+}
+
+func TestWaitForNoError(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+
+	calls := 0
+	ok := is.WaitForNoError(time.Second, func() error {
+		calls++
+		if calls < 2 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+	ok = ok && !is.WaitForNoError(150*time.Millisecond, func() error {
+		return fmt.Errorf("connection refused")
+	})
+
+	is = is.WithFailFunc(nil)
+	is.Strict().True(ok)
+	is.Strict().Equal(calls, 2)
+	is.Strict().Equal(hit, 1)
+	is.Strict().Equal(msg, "function did not return nil within the timeout of 150ms, last error: connection refused")
+}
+
+func TestShouldPanicValue(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+
+	value := is.ShouldPanicValue(func() { panic(errors.New("boom")) })
+	is.ShouldPanicWith(func() { panic("boom") }, "boom")
+	is.ShouldPanicWith(func() { panic(7) }, int64(7))
+	noValue := is.ShouldPanicValue(func() {})
+	is.ShouldPanicWith(func() {}, "boom")
+	panicWithOther := func() { panic("other") }
+	is.ShouldPanicWith(panicWithOther, "boom")
+
+	is = is.WithFailFunc(nil)
+	is.ErrMsg(value.(error), "boom")
+	is.Nil(noValue)
+	is.Equal(hit, 3)
+	is.True(strings.HasPrefix(msg, "expected function to panic with boom (string), but it panicked with other (string) at:\ngithub.com/ilius/is/v2.TestShouldPanicValue.func"))
+}
+
+func TestShouldPanicBefore(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+
+	ready := make(chan struct{})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(ready)
+	}()
+	value := is.ShouldPanicBefore(time.Second, func() {
+		<-ready
+		panic("closed")
+	})
+	is.ShouldPanicBefore(time.Second, func() {})
+	block := make(chan struct{})
+	defer close(block)
+	is.ShouldPanicBefore(10*time.Millisecond, func() { <-block })
+
+	is = is.WithFailFunc(nil)
+	is.Equal(value, "closed")
+	is.Equal(hit, 2)
+	is.Equal(msg, "expected function to panic within 10ms")
+}
+
+func TestNilKinds(t *testing.T) {
+	is := New(t)
+
+	var (
+		nilChan   chan int
+		nilFunc   func()
+		nilMap    map[string]int
+		nilPtr    *int
+		nilSlice  []int
+		nilUnsafe unsafe.Pointer
+		nilIface  fmt.Stringer
+		nilErr    *codeError
+	)
+	nils := []interface{}{
+		nil, nilChan, nilFunc, nilMap, nilPtr, nilSlice, nilUnsafe, nilIface,
+		interface{}(nilErr), error(nilErr),
+	}
+	x := 1
+	notNils := []interface{}{
+		make(chan int), func() {}, map[string]int{}, &x, []int{},
+		unsafe.Pointer(&x), error(&codeError{}), 0, "", struct{}{},
+	}
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	for _, o := range nils {
+		is.Nil(o)
+	}
+	for _, o := range notNils {
+		is.NotNil(o)
+	}
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, 0)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	for _, o := range nils {
+		is.NotNil(o)
+	}
+	for _, o := range notNils {
+		is.Nil(o)
+	}
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, len(nils)+len(notNils))
+}
+
+func TestNilStrict(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+
+	var nilErr *codeError
+	is.NilStrict(nil)
+	is.Nil(error(nilErr))
+	is.NotErr(nilErr)
+	is.Equal(hit, 0)
+
+	is.NilStrict(error(nilErr))
+	is.Equal(msg, "expected nil, but got a non-nil interface containing typed nil (*is.codeError)(nil)")
+	is.NilStrict(1)
+	is.Equal(msg, "expected nil, but got: 1 (int)")
+	is.Err(nilErr)
+	is.Equal(msg, "expected error, but got a non-nil interface containing typed nil (*is.codeError)(nil)")
+	is.Err(nil)
+	is.Equal(msg, "expected error")
+
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, 4)
+}
+
+// revisioned implements Equaler ignoring its revision.
+type revisioned struct {
+	n   int
+	rev int
+}
+
+func (r revisioned) Equal(in interface{}) bool {
+	o, ok := in.(revisioned)
+	return ok && r.n == o.n
+}
+
+func TestZeroKinds(t *testing.T) {
+	is := New(t)
+
+	var (
+		nilPtr    *testStruct
+		nilFunc   func()
+		nilUnsafe unsafe.Pointer
+	)
+	zeros := []interface{}{
+		nil, nilPtr, &testStruct{}, nilFunc, nilUnsafe, [3]int{}, time.Time{},
+		&time.Time{}, revisioned{rev: 3}, []int{}, map[string]int{},
+		error((*codeError)(nil)),
+	}
+	x := 1
+	notZeros := []interface{}{
+		&x, [3]int{0, 1, 0}, time.Unix(0, 0), func() {}, unsafe.Pointer(&x),
+		testStruct{v: 1}, revisioned{n: 1}, []int{0},
+	}
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	for _, o := range zeros {
+		is.Zero(o)
+	}
+	for _, o := range notZeros {
+		is.NotZero(o)
+	}
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, 0)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	for _, o := range zeros {
+		is.NotZero(o)
+	}
+	for _, o := range notZeros {
+		is.Zero(o)
+	}
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, len(zeros)+len(notZeros))
+}
+
+type selfPointer *selfPointer
+
+func TestEmptyKinds(t *testing.T) {
+	is := New(t)
+
+	var (
+		nilSlice []int
+		nilMap   map[string]int
+		nilChan  chan int
+		nilPtr   *[]int
+	)
+	emptyString, emptySlice, emptyMap := "", []int{}, map[string]int{}
+	emptyChan := make(chan int, 1)
+	fullChan := make(chan int, 1)
+	fullChan <- 1
+	str, slice, m := "a", []int{1}, map[string]int{"a": 1}
+	var self selfPointer
+	self = &self
+
+	tests := []struct {
+		name  string
+		o     interface{}
+		empty bool
+	}{
+		{"nil", nil, true},
+		{"nil slice", nilSlice, true},
+		{"empty slice", emptySlice, true},
+		{"slice", slice, false},
+		{"nil map", nilMap, true},
+		{"empty map", emptyMap, true},
+		{"map", m, false},
+		{"empty string", emptyString, true},
+		{"string", str, false},
+		{"nil channel", nilChan, true},
+		{"empty channel", emptyChan, true},
+		{"channel", fullChan, false},
+		{"nil pointer", nilPtr, true},
+		{"pointer to nil slice", &nilSlice, true},
+		{"pointer to empty slice", &emptySlice, true},
+		{"pointer to slice", &slice, false},
+		{"pointer to nil map", &nilMap, true},
+		{"pointer to empty map", &emptyMap, true},
+		{"pointer to map", &m, false},
+		{"pointer to empty string", &emptyString, true},
+		{"pointer to string", &str, false},
+		{"pointer to nil channel", &nilChan, true},
+		{"pointer to empty channel", &emptyChan, true},
+		{"pointer to channel", &fullChan, false},
+		{"pointer to pointer to slice", &nilPtr, true},
+		{"pointer to itself", self, false},
+	}
+	for _, test := range tests {
+		is.Msg(test.name).Equal(isEmpty(test.o), test.empty)
+	}
+
+	c := NewCollector()
+	is.True(c.Empty(&emptyChan))
+	is.False(c.Empty(self))
+	is.False(c.NotEmpty(&nilMap))
+	is.Len(c.Errs(), 2)
+}
+
+func TestSame(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+
+	a, b := 1, 1
+	p := &a
+	is.Same(p, &a)
+	is.Same(&a, &b)
+	is.Same(a, a)
+	is.Same(p, (*int64)(nil))
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 3)
+}
+
 type equaler struct {
 	equal  bool
 	called bool
@@ -352,9 +744,9 @@ func TestEqualer(t *testing.T) {
 	is := New(t)
 
 	hit := 0
-	fail = func(is *Is, format string, args ...interface{}) {
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
 		hit++
-	}
+	})
 
 	a := &equaler{equal: true}
 	b := &equaler{}
@@ -393,3 +785,168 @@ func TestEqualer(t *testing.T) {
 	}
 
 }
+
+type caseInsensitiveEqualer string
+
+func (s caseInsensitiveEqualer) Equal(in interface{}) bool {
+	o, ok := in.(string)
+	return ok && strings.EqualFold(string(s), o)
+}
+
+func TestIsOneOfMessages(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+
+	is.OneOf(7, 1, 6, 20)
+	if msg != `expected object 'int' to be equal to one of 3 candidates, but got: 7
+	[0] 1 (int)
+	[1] 6 (int) <- closest
+	[2] 20 (int)` {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+	is.OneOf("pending", "done", "pendng", "failed")
+	if !strings.Contains(msg, "[1] pendng (string) <- closest") {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+	is.OneOf([]int{1, 2, 3}, []int{4, 5, 6}, []int{1, 2, 4}, nil)
+	if !strings.Contains(msg, "[1] [1 2 4] ([]int) <- closest") {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+	is.NotOneOf(2, 1, 2, 3)
+	if msg != "expected object 'int' not to be equal to one of 'int,int,int', but it is equal to candidate [1]: 2" {
+		t.Fatalf("unexpected message: %s", msg)
+	}
+	is.Strict().Equal(hit, 4)
+
+	hit = 0
+	is.OneOf("OK", caseInsensitiveEqualer("ok"))
+	is.NotOneOf("KO", caseInsensitiveEqualer("ok"))
+	is.OneOf(3, 1.0, 3.0)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 0)
+}
+
+func TestCondition(t *testing.T) {
+	is := New(t)
+
+	warmed := true
+	is.Condition("cache warmed", func() bool { return warmed })
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	warmed = false
+	is.Condition("cache warmed", func() bool { return warmed })
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 1)
+	is.Equal(msg, "condition 'cache warmed' not met")
+}
+
+func TestCheck(t *testing.T) {
+	is := New(t)
+
+	is.True(is.Check(true, "unused"))
+
+	c := NewCollector()
+	is.False(c.Check(false, "expected %d items, got %d", 2, 3))
+	is.ErrMsg(c.Err(), "[Check] expected 2 items, got 3")
+}
+
+func TestClone(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	base := c.Msg("a %v", 1).AddMsg("b %v", 2).AddMsg("c %v", 3)
+	x := base.AddMsg("x %v", "X")
+	y := base.AddMsg("y %v", "Y")
+	x.True(false)
+	y.True(false)
+	is.ErrMsg(c.Errs()[0], "[True] expected boolean to be true - a 1 - b 2 - c 3 - x X")
+	is.ErrMsg(c.Errs()[1], "[True] expected boolean to be true - a 1 - b 2 - c 3 - y Y")
+
+	args := []interface{}{"user"}
+	msg := c.Msg("%v", args...)
+	args[0] = "changed"
+	msg.True(false)
+	is.ErrMsg(c.Errs()[2], "[True] expected boolean to be true - user")
+
+	withContext := c.WithContext("id", 7)
+	clone := withContext.Clone()
+	is.Len(clone.msgs, 0)
+	is.Equal(clone.context, withContext.context)
+	clone.context[0].value = 8
+	withContext.True(false)
+	is.ErrMsg(c.Errs()[3], "[True] expected boolean to be true\n\tid: 7")
+}
+
+func TestNameFormat(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	c.Len([]int{1}, 2)
+	c.WaitForTrue(time.Millisecond, func() bool { return false })
+	c.NameFormat("%s: ").True(false)
+	c.NameFormat("").True(false)
+	errs := c.Errs()
+	is.Len(errs, 4)
+	is.ErrMsgHasPrefix(errs[0], "[Len] ")
+	is.ErrMsgHasPrefix(errs[1], "[WaitForTrue] ")
+	is.ErrMsg(errs[2], "True: expected boolean to be true")
+	is.ErrMsg(errs[3], "expected boolean to be true")
+}
+
+func TestWarn(t *testing.T) {
+	is := New(t)
+
+	w := NewCollector()
+	var seen []AssertionResult
+	w.Intercept(func(next AssertFunc) AssertFunc {
+		return func(is *Is, r AssertionResult) {
+			seen = append(seen, r)
+			next(is, r)
+		}
+	}).Warn().True(false)
+	is.False(w.TB.Failed())
+	is.NotErr(w.Err())
+	is.Equal(w.Logs(), []string{"warning: [True] expected boolean to be true"})
+	is.Len(seen, 1)
+	is.True(seen[0].Failure.Warning)
+	failures := w.Failures()
+	is.Len(failures, 1)
+	is.True(failures[0].Warning)
+}
+
+func TestLaxLimit(t *testing.T) {
+	is := New(t)
+
+	reached := 0
+	tb := istest.Run(func(tb *istest.TB) {
+		limited := New(tb).LaxLimit(2)
+		limited.True(false)
+		limited.Warn().True(false)
+		limited.Msg("id %d", 7).Equal(1, 2)
+		reached++
+	})
+	is.Equal(reached, 0)
+	is.Equal(tb.Errors(), []string{"[True] expected boolean to be true"})
+	is.Equal(tb.Fatals(), []string{"[Equal] got '1' (int). expected '2' (int) - id 7\nstopping after 2 failures"})
+
+	tb = istest.Run(func(tb *istest.TB) {
+		limited := New(tb).LaxLimit(0)
+		limited.True(false)
+		limited.True(false)
+		reached++
+	})
+	is.Equal(reached, 1)
+	is.Len(tb.Errors(), 2)
+}
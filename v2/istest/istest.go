@@ -0,0 +1,183 @@
+// Package istest provides a fake testing.TB, for testing assertion helpers
+// built on github.com/ilius/is/v2 without failing the test running them.
+//
+// For example, checking that a custom assertion fails as expected:
+//
+//	func TestAssertValidUser(t *testing.T) {
+//		tb := istest.Run(func(tb *istest.TB) {
+//			assertValidUser(is.New(tb), User{})
+//		})
+//		if !tb.Failed() || len(tb.Fatals()) != 1 {
+//			t.Fatalf("unexpected failures: %q", tb.Fatals())
+//		}
+//	}
+package istest
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// TB is a fake testing.TB which records failures, logs and skips instead of
+// reporting them. It embeds a nil testing.TB to satisfy the interface, so
+// calling a method it does not implement panics. Error, Errorf, Fatal,
+// Fatalf, Fail, FailNow, Failed, Log, Logf, Skip, Skipf, SkipNow, Skipped,
+// Cleanup, Helper and Name are supported.
+//
+// Like the testing package, FailNow, Fatal, Fatalf and the skip methods
+// stop the calling goroutine with runtime.Goexit. Use Run to call code
+// which may stop, such as assertions of an Is in strict mode.
+type TB struct {
+	testing.TB
+	name string
+
+	mu       sync.Mutex
+	failed   bool
+	skipped  bool
+	errors   []string
+	fatals   []string
+	logs     []string
+	cleanups []func()
+}
+
+// NewTB returns a new fake testing.TB named name.
+func NewTB(name string) *TB {
+	return &TB{name: name}
+}
+
+// Run calls fn with a new fake testing.TB named "TestFake" in a separate
+// goroutine, waits for it to return or to stop with FailNow or SkipNow,
+// runs the functions registered with Cleanup, and returns the TB to
+// inspect what was recorded.
+func Run(fn func(tb *TB)) *TB {
+	tb := NewTB("TestFake")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(tb)
+	}()
+	<-done
+	tb.Finish()
+	return tb
+}
+
+// Finish runs the functions registered with Cleanup, in reverse order, as
+// the testing package does at the end of a test. Run calls it.
+func (tb *TB) Finish() {
+	tb.mu.Lock()
+	cleanups := tb.cleanups
+	tb.cleanups = nil
+	tb.mu.Unlock()
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+// Errors returns the messages passed to Error and Errorf.
+func (tb *TB) Errors() []string {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return append([]string(nil), tb.errors...)
+}
+
+// Fatals returns the messages passed to Fatal and Fatalf.
+func (tb *TB) Fatals() []string {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return append([]string(nil), tb.fatals...)
+}
+
+// Logs returns the messages passed to Log, Logf, Skip and Skipf.
+func (tb *TB) Logs() []string {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return append([]string(nil), tb.logs...)
+}
+
+func (tb *TB) Helper() {}
+
+func (tb *TB) Name() string {
+	return tb.name
+}
+
+func (tb *TB) Cleanup(f func()) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+func (tb *TB) Fail() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.failed = true
+}
+
+func (tb *TB) FailNow() {
+	tb.Fail()
+	runtime.Goexit()
+}
+
+func (tb *TB) Failed() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.failed
+}
+
+func (tb *TB) Error(args ...interface{}) {
+	tb.record(&tb.errors, fmt.Sprint(args...))
+	tb.Fail()
+}
+
+func (tb *TB) Errorf(format string, args ...interface{}) {
+	tb.record(&tb.errors, fmt.Sprintf(format, args...))
+	tb.Fail()
+}
+
+func (tb *TB) Fatal(args ...interface{}) {
+	tb.record(&tb.fatals, fmt.Sprint(args...))
+	tb.FailNow()
+}
+
+func (tb *TB) Fatalf(format string, args ...interface{}) {
+	tb.record(&tb.fatals, fmt.Sprintf(format, args...))
+	tb.FailNow()
+}
+
+func (tb *TB) Log(args ...interface{}) {
+	tb.record(&tb.logs, fmt.Sprint(args...))
+}
+
+func (tb *TB) Logf(format string, args ...interface{}) {
+	tb.record(&tb.logs, fmt.Sprintf(format, args...))
+}
+
+func (tb *TB) Skip(args ...interface{}) {
+	tb.Log(args...)
+	tb.SkipNow()
+}
+
+func (tb *TB) Skipf(format string, args ...interface{}) {
+	tb.Logf(format, args...)
+	tb.SkipNow()
+}
+
+func (tb *TB) SkipNow() {
+	tb.mu.Lock()
+	tb.skipped = true
+	tb.mu.Unlock()
+	runtime.Goexit()
+}
+
+func (tb *TB) Skipped() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.skipped
+}
+
+func (tb *TB) record(messages *[]string, msg string) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	*messages = append(*messages, msg)
+}
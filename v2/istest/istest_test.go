@@ -0,0 +1,36 @@
+package istest_test
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2"
+	"github.com/ilius/is/v2/istest"
+)
+
+func TestRun(t *testing.T) {
+	is := is.New(t)
+
+	cleaned := false
+	reached := false
+	tb := istest.Run(func(tb *istest.TB) {
+		tb.Cleanup(func() { cleaned = true })
+		a := is.New(tb)
+		a.Lax().Equal(1, 2)
+		a.Msg("id %d", 7).True(false)
+		reached = true
+	})
+	is.True(tb.Failed())
+	is.False(reached)
+	is.True(cleaned)
+	is.Equal(tb.Errors(), []string{"[Equal] got '1' (int). expected '2' (int)"})
+	is.Equal(tb.Fatals(), []string{"[True] expected boolean to be true - id 7"})
+
+	tb = istest.Run(func(tb *istest.TB) {
+		is.New(tb).Warn().True(false)
+		tb.Skip("not now")
+	})
+	is.False(tb.Failed())
+	is.True(tb.Skipped())
+	is.Equal(tb.Logs(), []string{"warning: [True] expected boolean to be true", "not now"})
+	is.Len(tb.Errors(), 0)
+}
@@ -0,0 +1,431 @@
+package is
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// decodeJSON converts the provided object into its generic JSON form, made
+// of map[string]interface{}, []interface{}, string, float64, bool and nil.
+// Integers too large to be represented exactly by a float64 are decoded as
+// *big.Int instead, so that they are not compared rounded. Strings, byte
+// slices and json.RawMessage are parsed as JSON documents, any other object
+// is marshaled first. Data after the document is an error.
+func decodeJSON(o interface{}) (interface{}, error) {
+	data, err := jsonData(o)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value at offset %d", dec.InputOffset())
+	}
+	return decodeJSONNumbers(v), nil
+}
+
+// maxExactFloat is the largest integer up to which all integers can be
+// represented by a float64.
+const maxExactFloat = 1 << 53
+
+// decodeJSONNumbers replaces the json.Number values in the decoded JSON
+// value v with float64 values, or *big.Int values for integers too large
+// for a float64.
+func decodeJSONNumbers(v interface{}) interface{} {
+	switch x := v.(type) {
+	case json.Number:
+		if n, ok := new(big.Int).SetString(x.String(), 10); ok && n.CmpAbs(big.NewInt(maxExactFloat)) > 0 {
+			return n
+		}
+		f, _ := x.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = decodeJSONNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = decodeJSONNumbers(e)
+		}
+	}
+	return v
+}
+
+// jsonData returns the JSON document held by the provided object, accepted
+// like with decodeJSON.
+func jsonData(o interface{}) ([]byte, error) {
+	switch v := o.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	}
+	return json.Marshal(o)
+}
+
+// encodeJSON returns the compact JSON encoding of a decoded JSON value for
+// failure messages.
+func encodeJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// marshalJSON converts the provided object into its generic JSON form by
+// marshaling and decoding it. Unlike decodeJSON, strings are never parsed
+// as JSON documents.
+func marshalJSON(o interface{}) (interface{}, error) {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(data)
+}
+
+// parseJSONPath splits a path such as "$.items[2].id" or `users["a.b"]` into
+// its segments, which are either string object keys or int array indexes.
+// The leading "$" is optional.
+func parseJSONPath(path string) ([]interface{}, error) {
+	var segs []interface{}
+	p := strings.TrimPrefix(path, "$")
+	for i := 0; i < len(p); {
+		if p[i] == '[' {
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ']'", path)
+			}
+			inner := p[i+1 : i+end]
+			if unquoted, err := strconv.Unquote(inner); err == nil {
+				segs = append(segs, unquoted)
+			} else if n, err := strconv.Atoi(inner); err == nil {
+				segs = append(segs, n)
+			} else {
+				return nil, fmt.Errorf("invalid path %q: %q is neither an index nor a quoted key", path, inner)
+			}
+			i += end + 1
+			continue
+		}
+		if p[i] == '.' {
+			i++
+		}
+		end := strings.IndexAny(p[i:], ".[")
+		if end < 0 {
+			end = len(p) - i
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("invalid path %q: empty key at offset %d", path, i)
+		}
+		segs = append(segs, p[i:i+end])
+		i += end
+	}
+	return segs, nil
+}
+
+// lookupJSONPath returns the element of a decoded JSON value at the path.
+func lookupJSONPath(v interface{}, path string) (interface{}, error) {
+	segs, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	at := "$"
+	for _, seg := range segs {
+		switch s := seg.(type) {
+		case string:
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an object: %s", at, encodeJSON(v))
+			}
+			v, ok = obj[s]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found in %s", s, at)
+			}
+			at += "." + s
+		case int:
+			arr, ok := v.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s is not an array: %s", at, encodeJSON(v))
+			}
+			if s < 0 || s >= len(arr) {
+				return nil, fmt.Errorf("index %d out of range for %s of length %d", s, at, len(arr))
+			}
+			v = arr[s]
+			at += fmt.Sprintf("[%d]", s)
+		}
+	}
+	return v, nil
+}
+
+// JSONPath checks the element at path of the provided JSON document to
+// determine if it is equal to expected once marshaled to JSON. The document
+// may be a string, a []byte or a json.RawMessage holding JSON, or any other
+// object, which is marshaled to JSON first.
+//
+// The path is made of dotted object keys and bracketed array indexes, such
+// as "items[2].id". It may start with "$", and keys containing dots or
+// brackets may be quoted in brackets, such as `labels["app.kubernetes.io"]`.
+func (is *Is) JSONPath(doc interface{}, path string, expected interface{}) bool {
+	is.TB.Helper()
+	d, err := decodeJSON(doc)
+	if err != nil {
+		is.fail("expected object to be JSON: %v", err)
+		return false
+	}
+	a, err := lookupJSONPath(d, path)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	e, err := marshalJSON(expected)
+	if err != nil {
+		is.fail("failed to marshal expected value: %v", err)
+		return false
+	}
+	if !reflect.DeepEqual(a, e) {
+		is.fail("got %s at %s. expected %s", encodeJSON(a), path, encodeJSON(e))
+		return false
+	}
+	return passed(is)
+}
+
+// jsonSubsetDiff appends to problems a description of each element of the
+// decoded JSON value expected which is missing from, or different in, the
+// decoded JSON value actual. Unless exact is set, objects in actual may have
+// extra keys. Arrays must have the same length.
+func jsonSubsetDiff(at string, actual interface{}, expected interface{}, exact bool, problems []string) []string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return append(problems, fmt.Sprintf("%s: got %s, expected an object", at, encodeJSON(actual)))
+		}
+		keys := make([]string, 0, len(e))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			av, ok := a[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: missing", at, key))
+				continue
+			}
+			problems = jsonSubsetDiff(at+"."+key, av, e[key], exact, problems)
+		}
+		if exact {
+			extra := make([]string, 0, len(a))
+			for key := range a {
+				if _, ok := e[key]; !ok {
+					extra = append(extra, key)
+				}
+			}
+			sort.Strings(extra)
+			for _, key := range extra {
+				problems = append(problems, fmt.Sprintf("%s.%s: unexpected %s", at, key, encodeJSON(a[key])))
+			}
+		}
+		return problems
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return append(problems, fmt.Sprintf("%s: got %s, expected an array", at, encodeJSON(actual)))
+		}
+		if len(a) != len(e) {
+			return append(problems, fmt.Sprintf("%s: got array of length %d, expected %d", at, len(a), len(e)))
+		}
+		for i := range e {
+			problems = jsonSubsetDiff(fmt.Sprintf("%s[%d]", at, i), a[i], e[i], exact, problems)
+		}
+		return problems
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		return append(problems, fmt.Sprintf("%s: got %s, expected %s", at, encodeJSON(actual), encodeJSON(expected)))
+	}
+	return problems
+}
+
+// JSONContains checks the provided JSON documents to determine if expected
+// is a structural subset of actual: every key of every object in expected
+// must be present in actual with a matching value, while extra keys in
+// actual are ignored. Arrays must have the same length and their elements
+// are matched by position.
+//
+// Both documents may be a string, a []byte or a json.RawMessage holding
+// JSON, or any other object, which is marshaled to JSON first. All missing
+// and mismatched paths are reported on failure.
+func (is *Is) JSONContains(actual interface{}, expected interface{}) bool {
+	is.TB.Helper()
+	a, err := decodeJSON(actual)
+	if err != nil {
+		is.fail("expected actual object to be JSON: %v", err)
+		return false
+	}
+	e, err := decodeJSON(expected)
+	if err != nil {
+		is.fail("expected object to be JSON: %v", err)
+		return false
+	}
+	problems := jsonSubsetDiff("$", a, e, false, nil)
+	if is.jsonKeyOrder {
+		problems = jsonKeyOrderDiff(actual, expected, problems)
+	}
+	if len(problems) > 0 {
+		is.fail("expected JSON to contain %s, but:\n\t%s", encodeJSON(e), strings.Join(problems, "\n\t"))
+		return false
+	}
+	return passed(is)
+}
+
+// JSONEq checks the provided JSON documents to determine if they are
+// semantically equal, ignoring formatting and the order of object keys,
+// unless JSONKeyOrder is used. The documents are accepted like with
+// JSONContains, and all missing, unexpected and mismatched paths are
+// reported on failure.
+func (is *Is) JSONEq(actual interface{}, expected interface{}) bool {
+	is.TB.Helper()
+	a, err := decodeJSON(actual)
+	if err != nil {
+		is.fail("expected actual object to be JSON: %v", err)
+		return false
+	}
+	e, err := decodeJSON(expected)
+	if err != nil {
+		is.fail("expected object to be JSON: %v", err)
+		return false
+	}
+	problems := jsonSubsetDiff("$", a, e, true, nil)
+	if is.jsonKeyOrder {
+		problems = jsonKeyOrderDiff(actual, expected, problems)
+	}
+	if len(problems) > 0 {
+		is.fail("expected JSON to equal %s, but:\n\t%s", encodeJSON(e), strings.Join(problems, "\n\t"))
+		return false
+	}
+	return passed(is)
+}
+
+// JSONKeyOrder returns a copy of this instance of Is whose JSONEq and
+// JSONContains assertions also check that the keys of each object of
+// actual are in the same order as in expected, for tests of canonical
+// output such as deterministic marshalers. Keys missing from either object
+// are ignored by this check, as they are reported anyway. Objects other
+// than JSON documents are marshaled first, which sorts the keys of maps.
+func (is *Is) JSONKeyOrder() *Is {
+	newIs := *is
+	newIs.jsonKeyOrder = true
+	return &newIs
+}
+
+// jsonKeyOrders returns the keys of each object of a JSON document, in
+// order, by path like "$.items[2]".
+func jsonKeyOrders(data []byte) (map[string][]string, error) {
+	orders := map[string][]string{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var walk func(at string) error
+	walk = func(at string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'):
+			keys := []string{}
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key := tok.(string)
+				keys = append(keys, key)
+				if err := walk(at + "." + key); err != nil {
+					return err
+				}
+			}
+			orders[at] = keys
+			_, err = dec.Token()
+			return err
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+			return err
+		}
+		return nil
+	}
+	if err := walk("$"); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// jsonKeyOrderDiff appends to problems the objects whose keys are in a
+// different order in the actual JSON document than in the expected one,
+// considering only the keys present in both.
+func jsonKeyOrderDiff(actual interface{}, expected interface{}, problems []string) []string {
+	aData, err := jsonData(actual)
+	if err != nil {
+		return problems
+	}
+	eData, err := jsonData(expected)
+	if err != nil {
+		return problems
+	}
+	aOrders, err := jsonKeyOrders(aData)
+	if err != nil {
+		return problems
+	}
+	eOrders, err := jsonKeyOrders(eData)
+	if err != nil {
+		return problems
+	}
+	paths := make([]string, 0, len(eOrders))
+	for path := range eOrders {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		aKeys, ok := aOrders[path]
+		if !ok {
+			continue
+		}
+		eKeys := eOrders[path]
+		a := commonKeys(aKeys, eKeys)
+		e := commonKeys(eKeys, aKeys)
+		if strings.Join(a, "\x00") != strings.Join(e, "\x00") {
+			problems = append(problems, fmt.Sprintf("%s: keys in order %q, expected %q", path, a, e))
+		}
+	}
+	return problems
+}
+
+// commonKeys returns the keys of keys which are also in other, in order.
+func commonKeys(keys []string, other []string) []string {
+	in := make(map[string]bool, len(other))
+	for _, key := range other {
+		in[key] = true
+	}
+	common := []string{}
+	for _, key := range keys {
+		if in[key] {
+			common = append(common, key)
+		}
+	}
+	return common
+}
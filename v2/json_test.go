@@ -0,0 +1,145 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestJSONPath(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	doc := `{"items": [{"id": 1}, {"id": 2}, {"id": 3, "tags": ["x"]}], "labels": {"app.name": "is"}}`
+	is.JSONPath(doc, "items[2].id", 3)
+	is.JSONPath(doc, "$.items[2].tags", []string{"x"})
+	is.JSONPath(doc, `labels["app.name"]`, "is")
+	is.JSONPath(doc, "$.items[0]", map[string]int{"id": 1})
+	is.JSONPath([]byte(`[1, [2, 3]]`), "[1][0]", 2)
+	is.JSONPath(struct {
+		Name string `json:"name"`
+	}{Name: "bob"}, "name", "bob")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.JSONPath(doc, "items[2].id", 4)
+	is.Equal(msg, "got 3 at items[2].id. expected 4")
+	is.JSONPath(doc, "items[3].id", 3)
+	is.Equal(msg, "index 3 out of range for $.items of length 3")
+	is.JSONPath(doc, "items.id", 3)
+	is.JSONPath(doc, "labels.app", "is")
+	is.Equal(msg, `key "app" not found in $.labels`)
+	is.JSONPath(doc, "items[x]", 3)
+	is.JSONPath(doc, "items[0", 3)
+	is.JSONPath(doc, "items..id", 3)
+	is.JSONPath("{", "a", 3)
+	is.JSONPath(doc, "items", func() {})
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 9)
+}
+
+func TestJSONContains(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	actual := `{"id": 7, "name": "bob", "roles": [{"name": "admin", "since": 2020}], "meta": {"v": 2, "etag": "x"}}`
+	is.JSONContains(actual, `{}`)
+	is.JSONContains(actual, `{"name": "bob", "meta": {"v": 2}}`)
+	is.JSONContains(actual, `{"roles": [{"name": "admin"}]}`)
+	is.JSONContains(actual, map[string]interface{}{"id": 7})
+	is.JSONContains(actual, actual)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.JSONContains(actual, `{"name": "alice", "email": "a@b.c", "meta": {"v": 3}, "roles": []}`)
+	is.Equal(msg, `expected JSON to contain {"email":"a@b.c","meta":{"v":3},"name":"alice","roles":[]}, but:
+	$.email: missing
+	$.meta.v: got 2, expected 3
+	$.name: got "bob", expected "alice"
+	$.roles: got array of length 1, expected 0`)
+	is.JSONContains(actual, `{"id": {"a": 1}, "name": [1]}`)
+	is.JSONContains(actual, `{`)
+	is.JSONContains(`[`, `{}`)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 4)
+}
+
+func TestJSONEq(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.JSONEq(`{"a": 1, "b": [true, null]}`, "{\n\t\"b\": [true, null],\n\t\"a\": 1\n}")
+	is.JSONEq([]byte(`[1, 2]`), []int{1, 2})
+	is.JSONEq(`{"a": 1.0} `, `{"a": 1}`)
+	is.JSONEq(`[9007199254740993]`, []uint64{9007199254740993})
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.JSONEq(`{"a": 1, "b": {"c": 2, "d": 3}}`, `{"a": 2, "b": {"c": 2}}`)
+	is.Equal(msg, `expected JSON to equal {"a":2,"b":{"c":2}}, but:
+	$.a: got 1, expected 2
+	$.b.d: unexpected 3`)
+	is.JSONEq(`{`, `{}`)
+	is.JSONEq(`{"a":1}xxx`, `{"a":1}`)
+	is.JSONEq(`{}{}`, `{}`)
+	is.JSONEq(`[9007199254740993]`, `[9007199254740992]`)
+	is.Equal(msg, `expected JSON to equal [9007199254740992], but:
+	$[0]: got 9007199254740993, expected 9007199254740992`)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 5)
+}
+
+func TestJSONKeyOrder(t *testing.T) {
+	is := New(t)
+
+	type point struct {
+		Y int `json:"y"`
+		X int `json:"x"`
+	}
+	ordered := is.JSONKeyOrder()
+	ordered.JSONEq(`{"y": 1, "x": 2}`, point{Y: 1, X: 2})
+	ordered.JSONEq(`{"b": 1, "a": 2}`, `{"b": 1, "a": 2}`)
+	ordered.JSONContains(`{"b": 1, "c": 3, "a": 2}`, `{"b": 1, "a": 2}`)
+	is.JSONEq(`{"b": 1, "a": 2}`, `{"a": 2, "b": 1}`)
+
+	hit := 0
+	var msgs []string
+	ordered = ordered.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	})
+	ordered.JSONEq(`{"b": 1, "a": 2}`, `{"a": 2, "b": 1}`)
+	ordered.JSONContains(`{"items": [{"id": 1, "name": "x"}], "z": 0}`, `{"items": [{"name": "x", "id": 1}]}`)
+	ordered.JSONEq(`{"a": 1, "c": 3, "b": 2}`, `{"a": 1, "b": 2, "d": 4}`)
+	is.Strict().Equal(hit, 3)
+	is.Equal(msgs[0], `expected JSON to equal {"a":2,"b":1}, but:
+	$: keys in order ["b" "a"], expected ["a" "b"]`)
+	is.Equal(msgs[1], `expected JSON to contain {"items":[{"id":1,"name":"x"}]}, but:
+	$.items[0]: keys in order ["id" "name"], expected ["name" "id"]`)
+	is.Equal(msgs[2], `expected JSON to equal {"a":1,"b":2,"d":4}, but:
+	$.d: missing
+	$.c: unexpected 3`)
+}
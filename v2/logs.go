@@ -0,0 +1,117 @@
+package is
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// LogCapture holds the log output captured by CaptureLog.
+type LogCapture struct {
+	is  *Is
+	out string
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent writes, as loggers
+// may be used from several goroutines.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// CaptureLog calls fn while redirecting the output of the standard logger,
+// and of the provided loggers, and returns the captured output. The
+// previous outputs are restored when fn returns, even if it panics.
+//
+// For example:
+//
+//	logs := is.CaptureLog(func() {
+//		server.Shutdown()
+//	})
+//	logs.Contains("server stopped")
+func (is *Is) CaptureLog(fn func(), loggers ...*log.Logger) *LogCapture {
+	is.TB.Helper()
+	var buf lockedBuffer
+	loggers = append([]*log.Logger{log.Default()}, loggers...)
+	for _, l := range loggers {
+		defer l.SetOutput(l.Writer())
+		l.SetOutput(&buf)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				is.fail("function panicked while capturing logs: %v", r)
+			}
+		}()
+		fn()
+	}()
+	return &LogCapture{is: is, out: buf.String()}
+}
+
+// String returns the captured output.
+func (c *LogCapture) String() string {
+	return c.out
+}
+
+// Lines returns the captured output split into lines, without the trailing
+// empty line.
+func (c *LogCapture) Lines() []string {
+	if c.out == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(c.out, "\n"), "\n")
+}
+
+// Contains checks the captured output to determine if it contains substr.
+func (c *LogCapture) Contains(substr string) bool {
+	is := c.is
+	is.TB.Helper()
+	if !strings.Contains(c.out, substr) {
+		is.fail("expected log output to contain %q, but got: %q", substr, c.out)
+		return false
+	}
+	return passed(is)
+}
+
+// Matches checks the captured output to determine if it matches the
+// regular expression pattern. Use the (?m) flag to match individual lines
+// with ^ and $.
+func (c *LogCapture) Matches(pattern string) bool {
+	is := c.is
+	is.TB.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		is.fail("invalid pattern %q: %v", pattern, err)
+		return false
+	}
+	if !re.MatchString(c.out) {
+		is.fail("expected log output to match %q, but got: %q", pattern, c.out)
+		return false
+	}
+	return passed(is)
+}
+
+// LineCount checks the captured output to determine if it has n lines.
+func (c *LogCapture) LineCount(n int) bool {
+	is := c.is
+	is.TB.Helper()
+	if lines := c.Lines(); len(lines) != n {
+		is.fail("expected %d log lines, but got %d: %q", n, len(lines), c.out)
+		return false
+	}
+	return passed(is)
+}
@@ -0,0 +1,50 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestCaptureLog(t *testing.T) {
+	is := New(t)
+
+	var other bytes.Buffer
+	l := log.New(&other, "svc: ", 0)
+	flags := log.Flags()
+	log.SetFlags(0)
+	defer log.SetFlags(flags)
+
+	logs := is.CaptureLog(func() {
+		log.Print("starting")
+		l.Printf("listening on %d", 80)
+	}, l)
+	is.Equal(log.Writer(), os.Stderr)
+	is.Equal(other.Len(), 0)
+	is.Equal(logs.String(), "starting\nsvc: listening on 80\n")
+	is.Equal(logs.Lines(), []string{"starting", "svc: listening on 80"})
+	logs.Contains("listening")
+	logs.Matches(`(?m)^svc: listening on \d+$`)
+	logs.LineCount(2)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	logs.is = is
+	logs.Contains("stopped")
+	is.Strict().Equal(msg, `expected log output to contain "stopped", but got: "starting\nsvc: listening on 80\n"`)
+	logs.Matches("^listening")
+	logs.Matches("(")
+	logs.LineCount(3)
+	empty := is.CaptureLog(func() { panic("boom") })
+	empty.LineCount(0)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 5)
+	is.Equal(log.Writer(), os.Stderr)
+}
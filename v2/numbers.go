@@ -0,0 +1,307 @@
+package is
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// compareValues compares two numbers of any kinds, or two strings, and
+// returns -1, 0 or 1 if a is less than, equal to or greater than b. ok is
+// false if they can not be compared, which includes NaN unless opts equates
+// NaNs. If opts distinguishes negative zero, -0.0 is less than 0.0.
+func compareValues(a interface{}, b interface{}, opts equalOptions) (result int, ok bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() == reflect.String && bv.Kind() == reflect.String {
+		switch {
+		case av.String() < bv.String():
+			return -1, true
+		case av.String() > bv.String():
+			return 1, true
+		}
+		return 0, true
+	}
+	ai, aIsInt := toInt64(av)
+	bi, bIsInt := toInt64(bv)
+	if aIsInt && bIsInt {
+		switch {
+		case ai < bi:
+			return -1, true
+		case ai > bi:
+			return 1, true
+		}
+		return 0, true
+	}
+	af, aOK := toFloat64(av)
+	bf, bOK := toFloat64(bv)
+	if !aOK || !bOK {
+		return 0, false
+	}
+	if math.IsNaN(af) || math.IsNaN(bf) {
+		if opts.equateNaNs && math.IsNaN(af) && math.IsNaN(bf) {
+			return 0, true
+		}
+		return 0, false
+	}
+	switch {
+	case af < bf:
+		return -1, true
+	case af > bf:
+		return 1, true
+	case opts.distinguishNegativeZero && af == 0 && math.Signbit(af) != math.Signbit(bf):
+		if math.Signbit(af) {
+			return -1, true
+		}
+		return 1, true
+	}
+	return 0, true
+}
+
+// toInt64 converts an integer of any kind to an int64. ok is false for
+// other kinds and for unsigned integers too large for an int64.
+func toInt64(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v.Uint()), true
+	}
+	return 0, false
+}
+
+// toFloat64 converts a number of any kind to a float64. ok is false for
+// other kinds.
+func toFloat64(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// Greater checks the provided objects to determine if a is greater than b.
+// Both must be numbers, possibly of different kinds, or both strings. NaN
+// is not comparable, unless EquateNaNs is used, in which case it is only
+// equal to NaN. With DistinguishNegativeZero, 0.0 is greater than -0.0.
+func (is *Is) Greater(a interface{}, b interface{}) bool {
+	is.TB.Helper()
+	c, ok := compareValues(a, b, is.equalOpts)
+	if !ok {
+		is.fail("expected objects '%s' and '%s' to be comparable numbers or strings", objectTypeName(a), objectTypeName(b))
+		return false
+	}
+	if c <= 0 {
+		is.fail("expected %s to be greater than %s", is.formatValue(a), is.formatValue(b))
+		return false
+	}
+	return passed(is)
+}
+
+// Less checks the provided objects to determine if a is less than b. Both
+// must be numbers, possibly of different kinds, or both strings. NaN and
+// negative zero are handled as with Greater.
+func (is *Is) Less(a interface{}, b interface{}) bool {
+	is.TB.Helper()
+	c, ok := compareValues(a, b, is.equalOpts)
+	if !ok {
+		is.fail("expected objects '%s' and '%s' to be comparable numbers or strings", objectTypeName(a), objectTypeName(b))
+		return false
+	}
+	if c >= 0 {
+		is.fail("expected %s to be less than %s", is.formatValue(a), is.formatValue(b))
+		return false
+	}
+	return passed(is)
+}
+
+// InDelta checks the provided numbers to determine if actual is within
+// delta of expected. NaN is not within any delta of anything, unless
+// EquateNaNs is used, in which case NaN is within any delta of NaN.
+// DistinguishNegativeZero does not apply, as -0.0 and 0.0 are within any
+// delta of each other.
+func (is *Is) InDelta(actual interface{}, expected interface{}, delta float64) bool {
+	is.TB.Helper()
+	a, okA := toFloat64(reflect.ValueOf(actual))
+	e, okE := toFloat64(reflect.ValueOf(expected))
+	if actual == nil || expected == nil || !okA || !okE {
+		is.fail("expected objects '%s' and '%s' to be numbers", objectTypeName(actual), objectTypeName(expected))
+		return false
+	}
+	if is.equalOpts.equateNaNs && math.IsNaN(a) && math.IsNaN(e) {
+		return passed(is)
+	}
+	if math.IsNaN(a) || math.IsNaN(e) || math.Abs(a-e) > delta {
+		is.fail("expected %v to be within %v of %v, but the difference is %v", a, delta, e, math.Abs(a-e))
+		return false
+	}
+	return passed(is)
+}
+
+// numberSign returns -1, 0 or 1 if the number o is negative, zero or
+// positive. Numbers of any kind, *big.Int, *big.Float and *big.Rat are
+// supported. ok is false for other objects, nil big numbers and NaN.
+func numberSign(o interface{}) (sign int, ok bool) {
+	switch n := o.(type) {
+	case *big.Int:
+		if n != nil {
+			return n.Sign(), true
+		}
+		return 0, false
+	case *big.Float:
+		if n != nil {
+			return n.Sign(), true
+		}
+		return 0, false
+	case *big.Rat:
+		if n != nil {
+			return n.Sign(), true
+		}
+		return 0, false
+	}
+	v := reflect.ValueOf(o)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case v.Int() < 0:
+			return -1, true
+		case v.Int() > 0:
+			return 1, true
+		}
+		return 0, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > 0 {
+			return 1, true
+		}
+		return 0, true
+	case reflect.Float32, reflect.Float64:
+		switch f := v.Float(); {
+		case math.IsNaN(f):
+			return 0, false
+		case f < 0:
+			return -1, true
+		case f > 0:
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// checkSign fails unless the number n has a sign accepted by valid, which
+// is described by what, such as "positive".
+func (is *Is) checkSign(n interface{}, what string, valid func(sign int) bool) bool {
+	is.TB.Helper()
+	sign, ok := numberSign(n)
+	if !ok {
+		is.fail("expected object '%s' to be a number, but got: %s", objectTypeName(n), is.formatValue(n))
+		return false
+	}
+	if !valid(sign) {
+		is.fail("expected %s to be %s", is.formatValue(n), what)
+		return false
+	}
+	return passed(is)
+}
+
+// Positive checks the provided number to determine if it is greater than
+// zero. Numbers of any kind, *big.Int, *big.Float and *big.Rat are
+// supported. NaN is not a number for this check.
+func (is *Is) Positive(n interface{}) bool {
+	is.TB.Helper()
+	return is.checkSign(n, "positive", func(sign int) bool { return sign > 0 })
+}
+
+// Negative checks the provided number to determine if it is less than zero,
+// see Positive.
+func (is *Is) Negative(n interface{}) bool {
+	is.TB.Helper()
+	return is.checkSign(n, "negative", func(sign int) bool { return sign < 0 })
+}
+
+// NonNegative checks the provided number to determine if it is greater than
+// or equal to zero, see Positive.
+func (is *Is) NonNegative(n interface{}) bool {
+	is.TB.Helper()
+	return is.checkSign(n, "non-negative", func(sign int) bool { return sign >= 0 })
+}
+
+// NonPositive checks the provided number to determine if it is less than or
+// equal to zero, see Positive.
+func (is *Is) NonPositive(n interface{}) bool {
+	is.TB.Helper()
+	return is.checkSign(n, "non-positive", func(sign int) bool { return sign <= 0 })
+}
+
+// toBigInt converts an integer of any kind, or a *big.Int, to a *big.Int.
+// ok is false for other objects.
+func toBigInt(o interface{}) (*big.Int, bool) {
+	if n, ok := o.(*big.Int); ok {
+		return n, n != nil
+	}
+	v := reflect.ValueOf(o)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), true
+	}
+	return nil, false
+}
+
+// DivisibleBy checks the provided integer n to determine if it is a
+// multiple of d. Both are integers of any kind, or *big.Int. The failure
+// message shows the remainder.
+func (is *Is) DivisibleBy(n interface{}, d interface{}) bool {
+	is.TB.Helper()
+	bn, okN := toBigInt(n)
+	bd, okD := toBigInt(d)
+	if !okN || !okD {
+		is.fail("expected objects '%s' and '%s' to be integers", objectTypeName(n), objectTypeName(d))
+		return false
+	}
+	if bd.Sign() == 0 {
+		is.fail("expected a non-zero divisor for %s", bn)
+		return false
+	}
+	if r := new(big.Int).Rem(bn, bd); r.Sign() != 0 {
+		is.fail("expected %s to be divisible by %s, but the remainder is %s", bn, bd, r)
+		return false
+	}
+	return passed(is)
+}
+
+// Aligned checks the provided integer v, such as an offset, a size or a
+// uintptr, to determine if it is a multiple of alignment, which must be
+// positive. The failure message shows how far v is past the previous
+// aligned value, and the next one.
+//
+//	is.Aligned(uintptr(unsafe.Pointer(&buf[0])), 64)
+func (is *Is) Aligned(v interface{}, alignment int) bool {
+	is.TB.Helper()
+	bv, ok := toBigInt(v)
+	if !ok {
+		is.fail("expected object '%s' to be an integer", objectTypeName(v))
+		return false
+	}
+	if alignment <= 0 {
+		is.fail("expected a positive alignment, got %d", alignment)
+		return false
+	}
+	ba := big.NewInt(int64(alignment))
+	if r := new(big.Int).Mod(bv, ba); r.Sign() != 0 {
+		next := new(big.Int).Add(bv, new(big.Int).Sub(ba, r))
+		is.fail("expected %s to be aligned to %d, but it is %s past an aligned value (next is %s)", bv, alignment, r, next)
+		return false
+	}
+	return passed(is)
+}
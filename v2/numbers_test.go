@@ -0,0 +1,146 @@
+package is
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestGreaterLess(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.Greater(2, 1)
+	is.Greater(int8(2), uint64(1))
+	is.Greater(1.5, 1)
+	is.Greater(uint64(math.MaxUint64), -1)
+	is.Greater("b", "a")
+	is.Less(-1, uint(0))
+	is.Less(1, 1.5)
+	is.Less("a", "b")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Greater(1, 1)
+	is.Equal(msg, "expected 1 to be greater than 1")
+	is.Less(2, 1)
+	is.Equal(msg, "expected 2 to be less than 1")
+	is.Greater("a", 1)
+	is.Less(math.NaN(), 1)
+	is.Less(nil, 1)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 5)
+}
+
+func TestInDelta(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.InDelta(1.05, 1, 0.1)
+	is.InDelta(int32(10), uint(12), 2)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.InDelta(1.5, 1, 0.1)
+	is.Equal(msg, "expected 1.5 to be within 0.1 of 1, but the difference is 0.5")
+	is.InDelta(math.NaN(), 1, 0.1)
+	is.InDelta("1", 1, 0.1)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 3)
+}
+
+func TestSign(t *testing.T) {
+	is := New(t)
+
+	is.Positive(1)
+	is.Positive(uint8(3))
+	is.Positive(0.5)
+	is.Positive(big.NewInt(7))
+	is.Negative(int64(-2))
+	is.Negative(big.NewFloat(-0.1))
+	is.Negative(big.NewRat(-1, 3))
+	is.NonNegative(0)
+	is.NonNegative(uint(0))
+	is.NonNegative(new(big.Int))
+	is.NonPositive(-1.5)
+	is.NonPositive(float32(0))
+
+	c := NewCollector()
+	c.Positive(0)
+	c.Negative(uint(1))
+	c.NonNegative(big.NewInt(-5))
+	c.NonPositive(time.Second)
+	c.Positive(math.NaN())
+	c.Negative((*big.Int)(nil))
+	c.Positive("1")
+	errs := c.Errs()
+	is.Len(errs, 7)
+	is.ErrMsg(errs[0], "[Positive] expected 0 to be positive")
+	is.ErrMsg(errs[1], "[Negative] expected 1 to be negative")
+	is.ErrMsg(errs[2], "[NonNegative] expected -5 to be non-negative")
+	is.ErrMsg(errs[3], "[NonPositive] expected 1s to be non-positive")
+	is.ErrMsg(errs[4], "[Positive] expected object 'float64' to be a number, but got: NaN")
+	is.ErrMsg(errs[5], "[Negative] expected object '*big.Int' to be a number, but got: <nil>")
+	is.ErrMsg(errs[6], "[Positive] expected object 'string' to be a number, but got: 1")
+}
+
+func TestDivisibleBy(t *testing.T) {
+	is := New(t)
+
+	is.DivisibleBy(12, 4)
+	is.DivisibleBy(uint64(math.MaxUint64), 5)
+	is.DivisibleBy(int8(-9), uint(3))
+	is.DivisibleBy(0, 7)
+	is.DivisibleBy(new(big.Int).Lsh(big.NewInt(1), 100), 1024)
+
+	c := NewCollector()
+	c.DivisibleBy(10, 4)
+	c.DivisibleBy(-10, 4)
+	c.DivisibleBy(10, 0)
+	c.DivisibleBy(1.5, 1)
+	errs := c.Errs()
+	is.Len(errs, 4)
+	is.ErrMsg(errs[0], "[DivisibleBy] expected 10 to be divisible by 4, but the remainder is 2")
+	is.ErrMsg(errs[1], "[DivisibleBy] expected -10 to be divisible by 4, but the remainder is -2")
+	is.ErrMsg(errs[2], "[DivisibleBy] expected a non-zero divisor for 10")
+	is.ErrMsg(errs[3], "[DivisibleBy] expected objects 'float64' and 'int' to be integers")
+}
+
+func TestAligned(t *testing.T) {
+	is := New(t)
+
+	is.Aligned(4096, 4096)
+	is.Aligned(uintptr(128), 64)
+	is.Aligned(0, 8)
+	is.Aligned(-16, 8)
+
+	c := NewCollector()
+	c.Aligned(4100, 4096)
+	c.Aligned(-3, 8)
+	c.Aligned(8, 0)
+	c.Aligned("8", 8)
+	errs := c.Errs()
+	is.Len(errs, 4)
+	is.ErrMsg(errs[0], "[Aligned] expected 4100 to be aligned to 4096, but it is 4 past an aligned value (next is 8192)")
+	is.ErrMsg(errs[1], "[Aligned] expected -3 to be aligned to 8, but it is 5 past an aligned value (next is 0)")
+	is.ErrMsg(errs[2], "[Aligned] expected a positive alignment, got 0")
+	is.ErrMsg(errs[3], "[Aligned] expected object 'string' to be an integer")
+}
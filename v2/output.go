@@ -0,0 +1,86 @@
+package is
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// outputMu serializes CaptureOutput calls, as they replace the global
+// os.Stdout and os.Stderr.
+var outputMu sync.Mutex
+
+// captureOutput calls fn with os.Stdout and os.Stderr redirected to pipes,
+// and returns what was written to them. The original files are restored
+// when fn returns, even if it panics.
+func captureOutput(fn func()) (stdout string, stderr string, err error) {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return "", "", err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		return "", "", err
+	}
+	var outBuf, errBuf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		io.Copy(&outBuf, outR)
+		outR.Close()
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(&errBuf, errR)
+		errR.Close()
+	}()
+
+	origOut, origErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	defer func() {
+		os.Stdout, os.Stderr = origOut, origErr
+		outW.Close()
+		errW.Close()
+		wg.Wait()
+		stdout, stderr = outBuf.String(), errBuf.String()
+	}()
+	fn()
+	return
+}
+
+// CaptureOutput calls fn while redirecting os.Stdout and os.Stderr, and
+// returns what was written to them. The original files are restored when fn
+// returns, even if it panics. Concurrent calls are serialized, but output
+// written by other goroutines while fn runs is captured as well.
+func (is *Is) CaptureOutput(fn func()) (stdout string, stderr string) {
+	is.TB.Helper()
+	stdout, stderr, err := captureOutput(fn)
+	if err != nil {
+		is.fail("failed to capture output: %v", err)
+	}
+	return stdout, stderr
+}
+
+// OutputContains calls fn like CaptureOutput, and checks its standard
+// output and standard error to determine if either contains substr.
+func (is *Is) OutputContains(fn func(), substr string) bool {
+	is.TB.Helper()
+	stdout, stderr, err := captureOutput(fn)
+	if err != nil {
+		is.fail("failed to capture output: %v", err)
+		return false
+	}
+	if !strings.Contains(stdout, substr) && !strings.Contains(stderr, substr) {
+		is.fail("expected output to contain %q, but got stdout: %q, stderr: %q", substr, stdout, stderr)
+		return false
+	}
+	return passed(is)
+}
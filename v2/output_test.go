@@ -0,0 +1,40 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestCaptureOutput(t *testing.T) {
+	is := New(t)
+
+	origOut, origErr := os.Stdout, os.Stderr
+	stdout, stderr := is.CaptureOutput(func() {
+		fmt.Println("hello")
+		fmt.Fprint(os.Stderr, "oops")
+	})
+	is.Equal(stdout, "hello\n")
+	is.Equal(stderr, "oops")
+	is.True(os.Stdout == origOut && os.Stderr == origErr)
+
+	is.OutputContains(func() { fmt.Print("done") }, "done")
+	is.OutputContains(func() { fmt.Fprint(os.Stderr, "warning") }, "warn")
+
+	is.ShouldPanic(func() {
+		is.CaptureOutput(func() { panic("boom") })
+	})
+	is.True(os.Stdout == origOut && os.Stderr == origErr)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.OutputContains(func() { fmt.Print("a") }, "b")
+
+	is = is.WithFailFunc(nil)
+	is.Equal(hit, 1)
+	is.Equal(msg, `expected output to contain "b", but got stdout: "a", stderr: ""`)
+}
@@ -0,0 +1,98 @@
+package is
+
+import (
+	"fmt"
+	"io"
+)
+
+// maxReaderSize is the number of bytes ReaderEqual and ReadersEqual read at
+// most from a reader, so that an endless stream does not hang the test.
+const maxReaderSize = 64 << 20
+
+// readAll reads r until EOF, failing if it returns an error or more than
+// maxReaderSize bytes. name identifies r in failure messages.
+func (is *Is) readAll(r io.Reader, name string) ([]byte, bool) {
+	is.TB.Helper()
+	data, err := io.ReadAll(io.LimitReader(r, maxReaderSize+1))
+	if err != nil {
+		is.fail("failed to read %s: %v", name, err)
+		return nil, false
+	}
+	if len(data) > maxReaderSize {
+		is.fail("%s is larger than the limit of %d bytes", name, maxReaderSize)
+		return nil, false
+	}
+	return data, true
+}
+
+// ReaderEqual reads r until EOF and fails if its contents are not equal to
+// expected, which is a string or a []byte. The failure message gives the
+// first offset where the contents differ, with the bytes around it. At most
+// 64 MiB are read.
+func (is *Is) ReaderEqual(r io.Reader, expected interface{}) bool {
+	is.TB.Helper()
+	var want []byte
+	switch x := expected.(type) {
+	case string:
+		want = []byte(x)
+	case []byte:
+		want = x
+	default:
+		is.fail("expected contents must be a string or a []byte, got %s", objectTypeName(expected))
+		return false
+	}
+	got, ok := is.readAll(r, "reader")
+	if !ok {
+		return false
+	}
+	if msg := bytesMismatch(got, want); msg != "" {
+		is.withValues(got, want).fail("reader contents differ %s", msg)
+		return false
+	}
+	return passed(is)
+}
+
+// ReadersEqual reads a and b until EOF and fails if their contents differ,
+// giving the first offset where they do. At most 64 MiB are read from each.
+func (is *Is) ReadersEqual(a io.Reader, b io.Reader) bool {
+	is.TB.Helper()
+	got, ok := is.readAll(a, "first reader")
+	if !ok {
+		return false
+	}
+	want, ok := is.readAll(b, "second reader")
+	if !ok {
+		return false
+	}
+	if msg := bytesMismatch(got, want); msg != "" {
+		is.withValues(got, want).fail("reader contents differ %s", msg)
+		return false
+	}
+	return passed(is)
+}
+
+// bytesMismatch describes where got and want first differ, or returns an
+// empty string if they are equal.
+func bytesMismatch(got []byte, want []byte) string {
+	const around = 16
+	i := 0
+	for i < len(got) && i < len(want) && got[i] == want[i] {
+		i++
+	}
+	if i == len(got) && i == len(want) {
+		return ""
+	}
+	start := i - around
+	if start < 0 {
+		start = 0
+	}
+	window := func(b []byte) []byte {
+		end := i + around
+		if end > len(b) {
+			end = len(b)
+		}
+		return b[start:end]
+	}
+	return fmt.Sprintf("at offset %d (got %d bytes, want %d): got %q, want %q",
+		i, len(got), len(want), window(got), window(want))
+}
@@ -0,0 +1,33 @@
+package is
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReaderEqual(t *testing.T) {
+	is := New(t)
+
+	is.ReaderEqual(strings.NewReader("hello"), "hello")
+	is.ReaderEqual(bytes.NewReader([]byte{1, 2}), []byte{1, 2})
+	is.ReaderEqual(strings.NewReader(""), "")
+	is.ReadersEqual(strings.NewReader("abc"), bytes.NewBufferString("abc"))
+
+	c := NewCollector()
+	c.ReaderEqual(strings.NewReader("hello world"), "hello there")
+	c.ReaderEqual(strings.NewReader("hello"), []byte("hello!"))
+	c.ReaderEqual(strings.NewReader("x"), 1)
+	c.ReaderEqual(iotest.ErrReader(errors.New("boom")), "")
+	c.ReadersEqual(strings.NewReader(strings.Repeat("a", 40)+"b"), strings.NewReader(strings.Repeat("a", 40)+"c"))
+	errs := c.Errs()
+	is.Len(errs, 5)
+	is.ErrMsg(errs[0], `[ReaderEqual] reader contents differ at offset 6 (got 11 bytes, want 11): got "hello world", want "hello there"`)
+	is.ErrMsg(errs[1], `[ReaderEqual] reader contents differ at offset 5 (got 5 bytes, want 6): got "hello", want "hello!"`)
+	is.ErrMsg(errs[2], `[ReaderEqual] expected contents must be a string or a []byte, got int`)
+	is.ErrMsg(errs[3], `[ReaderEqual] failed to read reader: boom`)
+	is.ErrMsg(errs[4], `[ReadersEqual] reader contents differ at offset 40 (got 41 bytes, want 41): got "aaaaaaaaaaaaaaaab", want "aaaaaaaaaaaaaaaac"`)
+	is.Equal(c.Failures()[0].Actual, []byte("hello world"))
+}
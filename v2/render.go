@@ -0,0 +1,107 @@
+package is
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+var (
+	renderFuncsMu sync.RWMutex
+	renderFuncs   = map[reflect.Type]func(o interface{}) string{}
+)
+
+func init() {
+	RegisterRenderer(func(d time.Duration) string { return d.String() })
+	RegisterRenderer(func(t time.Time) string { return t.Format(time.RFC3339Nano) })
+	RegisterRenderer(func(s ByteSize) string { return s.String() })
+}
+
+// RegisterRenderer registers the function used to render values of type T
+// in failure messages, instead of their String or Error method or their
+// contents. Registered functions also apply to values stored in unexported
+// struct fields, whose methods cannot be called otherwise.
+//
+// time.Duration, time.Time (in RFC 3339 format) and ByteSize are rendered
+// human-readably by default.
+func RegisterRenderer[T any](render func(T) string) {
+	renderFuncsMu.Lock()
+	defer renderFuncsMu.Unlock()
+	renderFuncs[reflect.TypeOf((*T)(nil)).Elem()] = func(o interface{}) string {
+		return render(o.(T))
+	}
+}
+
+// hasRenderer reports whether a function is registered to render values of
+// type t.
+func hasRenderer(t reflect.Type) bool {
+	renderFuncsMu.RLock()
+	defer renderFuncsMu.RUnlock()
+	_, ok := renderFuncs[t]
+	return ok
+}
+
+// registeredRender renders v with the function registered for its type.
+// ok is false if no function is registered or v cannot be read.
+func registeredRender(v reflect.Value) (s string, ok bool) {
+	renderFuncsMu.RLock()
+	render, ok := renderFuncs[v.Type()]
+	renderFuncsMu.RUnlock()
+	if !ok {
+		return "", false
+	}
+	o, ok := valueInterface(v)
+	if !ok {
+		return "", false
+	}
+	return render(o), true
+}
+
+// valueInterface returns the value held by v, like v.Interface, including
+// when v was obtained through an unexported struct field, as long as it is
+// addressable.
+func valueInterface(v reflect.Value) (interface{}, bool) {
+	if v.CanInterface() {
+		return v.Interface(), true
+	}
+	if !v.CanAddr() {
+		return nil, false
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem().Interface(), true
+}
+
+// ByteSize is a size in bytes, rendered with binary units such as "4.2MiB"
+// in failure messages. Convert sizes to ByteSize to compare them:
+//
+//	is.Equal(is.ByteSize(len(data)), is.ByteSize(4<<20))
+type ByteSize int64
+
+// String returns the size with the largest binary unit in which it is at
+// least 1, with one decimal, such as "512B", "1.5KiB" or "4.2MiB".
+func (s ByteSize) String() string {
+	n := int64(s)
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+	if n < 1024 {
+		return sign + strconv.FormatInt(n, 10) + "B"
+	}
+	value := float64(n)
+	unit := 0
+	// the value is rounded to one decimal before choosing the unit, so that
+	// 1048575 is 1MiB rather than 1024KiB
+	for math.Round(value*10)/10 >= 1024 && unit < len(byteUnits) {
+		value /= 1024
+		unit++
+	}
+	formatted := strings.TrimSuffix(strconv.FormatFloat(value, 'f', 1, 64), ".0")
+	return sign + formatted + byteUnits[unit-1]
+}
+
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
@@ -0,0 +1,165 @@
+package is
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+)
+
+// reportEnv is the environment variable which enables a FailureReport for
+// every Is created with New. Its value is the path of the report file.
+const reportEnv = "IS_REPORT"
+
+// failureValues holds the actual and expected values of a failing
+// assertion, for reporters.
+type failureValues struct {
+	actual   interface{}
+	expected interface{}
+	diff     string
+}
+
+// withValues returns a copy of this Is carrying the actual and expected
+// values of the assertion about to fail.
+func (is *Is) withValues(actual interface{}, expected interface{}) *Is {
+	newIs := *is
+	newIs.values = &failureValues{actual: actual, expected: expected}
+	return &newIs
+}
+
+// ReportedFailure is a failed assertion, as written by a FailureReport.
+type ReportedFailure struct {
+	Assertion string `json:"assertion"`
+	Message   string `json:"message"`
+	Caller    string `json:"caller,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+	Expected  string `json:"expected,omitempty"`
+	Diff      string `json:"diff,omitempty"`
+	Warning   bool   `json:"warning,omitempty"`
+}
+
+// ReportedTest is a test with failed assertions, as written by a
+// FailureReport.
+type ReportedTest struct {
+	Name     string            `json:"name"`
+	Failures []ReportedFailure `json:"failures"`
+}
+
+// FailureReport accumulates the failed assertions of each test and writes
+// them to a JSON file, for CI systems which attach rich failure context to
+// test reports, next to the JUnit report produced from the go test output.
+// The file is rewritten when each test with failures finishes, so it is
+// complete when the test binary exits. It is safe for concurrent use.
+type FailureReport struct {
+	mu    sync.Mutex
+	path  string
+	tests map[string]*ReportedTest
+	err   error
+}
+
+// NewFailureReport creates a new FailureReport which writes to the file at
+// path.
+func NewFailureReport(path string) *FailureReport {
+	return &FailureReport{path: path, tests: map[string]*ReportedTest{}}
+}
+
+// Report returns a copy of this Is which adds its failures to r, in
+// addition to reporting them to the testing object. Passing nil disables
+// the report.
+func (is *Is) Report(r *FailureReport) *Is {
+	newIs := *is
+	newIs.report = r
+	return &newIs
+}
+
+// Tests returns the tests with failures reported so far, sorted by name.
+func (r *FailureReport) Tests() []ReportedTest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sortedTests()
+}
+
+// Err returns the last error met while writing the report file, if any.
+func (r *FailureReport) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *FailureReport) sortedTests() []ReportedTest {
+	tests := make([]ReportedTest, 0, len(r.tests))
+	for _, t := range r.tests {
+		tests = append(tests, ReportedTest{
+			Name:     t.Name,
+			Failures: append([]ReportedFailure(nil), t.Failures...),
+		})
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		return tests[i].Name < tests[j].Name
+	})
+	return tests
+}
+
+// add records the failure f of the assertion being run, and registers the
+// test cleanup writing the report on the first failure of each test.
+func (r *FailureReport) add(is *Is, f Failure) {
+	rf := ReportedFailure{
+		Assertion: f.Assertion,
+		Message:   f.Message,
+		Caller:    f.Caller,
+		Diff:      f.Diff,
+		Warning:   f.Warning,
+	}
+	if is.values != nil {
+		rf.Actual = is.formatValue(f.Actual)
+		rf.Expected = is.formatValue(f.Expected)
+	}
+	testName := is.TB.Name()
+
+	r.mu.Lock()
+	t, ok := r.tests[testName]
+	if !ok {
+		t = &ReportedTest{Name: testName}
+		r.tests[testName] = t
+	}
+	t.Failures = append(t.Failures, rf)
+	first := !ok
+	r.mu.Unlock()
+
+	if _, ok := is.TB.(*handlerTB); ok {
+		// There is no end of test outside go test.
+		r.write()
+	} else if first {
+		is.TB.Cleanup(r.write)
+	}
+}
+
+// write writes the report file with all the failures reported so far.
+func (r *FailureReport) write() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(struct {
+		Tests []ReportedTest `json:"tests"`
+	}{r.sortedTests()}, "", "\t")
+	if err == nil {
+		err = os.WriteFile(r.path, append(data, '\n'), 0o644)
+	}
+	r.err = err
+}
+
+var (
+	envReportOnce sync.Once
+	envReport     *FailureReport
+)
+
+// EnvFailureReport returns the FailureReport used by every Is created with
+// New when the IS_REPORT environment variable is set to the path of the
+// report file, or nil if it is not set.
+func EnvFailureReport() *FailureReport {
+	envReportOnce.Do(func() {
+		if path := os.Getenv(reportEnv); path != "" {
+			envReport = NewFailureReport(path)
+		}
+	})
+	return envReport
+}
@@ -0,0 +1,74 @@
+package is
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFailureReport(t *testing.T) {
+	is := New(t)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	r := NewFailureReport(path)
+
+	t.Run("sub", func(t *testing.T) {
+		sub := is.New(t).Lax().Report(r)
+		sub = sub.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+			is.report.add(is, newFailure(is, fmt.Sprintf(format, args...)))
+		})
+		sub.Equal(1, 1)
+		sub.Equal([]int{1}, []int{2})
+		sub.Msg("id %d", 7).True(false)
+		_, err := os.Stat(path)
+		is.True(os.IsNotExist(err))
+	})
+
+	is.NotErr(r.Err())
+	data, err := os.ReadFile(path)
+	is.NotErr(err)
+	var doc struct {
+		Tests []ReportedTest `json:"tests"`
+	}
+	is.NotErr(json.Unmarshal(data, &doc))
+	is.Equal(doc.Tests, r.Tests())
+	is.Len(doc.Tests, 1)
+	is.Equal(doc.Tests[0].Name, "TestFailureReport/sub")
+	failures := doc.Tests[0].Failures
+	is.Len(failures, 2)
+	is.True(strings.HasPrefix(failures[0].Caller, filepath.Join(filepath.Dir(failures[0].Caller), "report_test.go:")))
+	failures[0].Caller = ""
+	failures[1].Caller = ""
+	is.Equal(failures, []ReportedFailure{
+		{
+			Assertion: "Equal",
+			Message:   "objects of type '[]int' differ at [0]: got 1, want 2",
+			Actual:    "[1]",
+			Expected:  "[2]",
+			Diff:      "[0]: got 1, want 2",
+		},
+		{
+			Assertion: "True",
+			Message:   "expected boolean to be true",
+		},
+	})
+}
+
+func TestFailureReportHandler(t *testing.T) {
+	is := New(t)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	r := NewFailureReport(path)
+	h := NewWithHandler(func(format string, args ...interface{}) {}).Report(r)
+	h.Equal(1, 2)
+	h.Equal(1, 3)
+
+	is.NotErr(r.Err())
+	data, err := os.ReadFile(path)
+	is.NotErr(err)
+	is.True(strings.Contains(string(data), `"expected": "3"`))
+	is.Len(r.Tests()[0].Failures, 2)
+}
@@ -0,0 +1,37 @@
+package is
+
+// Reporter adapts an Is to the minimal reporting interfaces used by other
+// testing libraries, such as gomock.TestReporter and gomock.TestHelper.
+// Failures reported through it go through the same pipeline as assertion
+// failures, so the message set with Msg is appended to them.
+type Reporter struct {
+	is *Is
+}
+
+// Reporter returns a Reporter backed by this Is.
+//
+// For example, to report mock expectation failures:
+//
+//	ctrl := gomock.NewController(is.Reporter())
+func (is *Is) Reporter() *Reporter {
+	return &Reporter{is: is}
+}
+
+// Errorf reports a failure and continues the test, regardless of whether
+// the Is is in Strict mode.
+func (r *Reporter) Errorf(format string, args ...interface{}) {
+	r.is.TB.Helper()
+	r.is.Lax().fail(format, args...)
+}
+
+// Fatalf reports a failure and stops the test, regardless of whether the Is
+// is in Lax mode.
+func (r *Reporter) Fatalf(format string, args ...interface{}) {
+	r.is.TB.Helper()
+	r.is.Strict().fail(format, args...)
+}
+
+// Helper marks the calling function as a test helper function.
+func (r *Reporter) Helper() {
+	r.is.TB.Helper()
+}
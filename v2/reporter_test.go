@@ -0,0 +1,25 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestReporter(t *testing.T) {
+	is := New(t)
+
+	var msgs []string
+	var strict []bool
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+		strict = append(strict, is.strict)
+	})
+	r := is.Msg("mock %d", 1).Reporter()
+	r.Helper()
+	r.Errorf("unexpected call to %s", "Get")
+	r.Fatalf("missing call to %s", "Put")
+
+	is = is.WithFailFunc(nil)
+	is.Equal(msgs, []string{"unexpected call to Get", "missing call to Put"})
+	is.Equal(strict, []bool{false, true})
+}
@@ -0,0 +1,37 @@
+package is
+
+import (
+	"reflect"
+)
+
+// RoundTrips checks that the provided value survives a marshal/unmarshal
+// cycle unchanged: v is encoded with marshal, decoded with unmarshal into a
+// new value of the same type, and the result is compared to v like with
+// Equal. The differences are reported on failure. For example:
+//
+//	is.RoundTrips(cfg, json.Marshal, json.Unmarshal)
+func (is *Is) RoundTrips(v interface{}, marshal func(interface{}) ([]byte, error), unmarshal func([]byte, interface{}) error) bool {
+	is.TB.Helper()
+	if v == nil {
+		is.fail("expected a value to round-trip, but got nil")
+		return false
+	}
+	data, err := marshal(v)
+	if err != nil {
+		is.fail("failed to marshal '%s': %v", objectTypeName(v), err)
+		return false
+	}
+	ptr := reflect.New(reflect.TypeOf(v))
+	if err := unmarshal(data, ptr.Interface()); err != nil {
+		is.fail("failed to unmarshal '%s' from %q: %v", objectTypeName(v), data, err)
+		return false
+	}
+	decoded := ptr.Elem().Interface()
+	equal, c := compareObjects(decoded, v, is.equalOpts, is.formatOpts)
+	if !equal {
+		format, args := is.notEqualMessage(decoded, v, c)
+		is.withComparison(decoded, v, c).fail("value changed after a round trip through %q: "+format, append([]interface{}{data}, args...)...)
+		return false
+	}
+	return passed(is)
+}
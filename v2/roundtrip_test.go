@@ -0,0 +1,41 @@
+package is
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type roundTripConfig struct {
+	Name  string `json:"name"`
+	Ports []int  `json:"ports"`
+	Token string `json:"-"`
+}
+
+func TestRoundTrips(t *testing.T) {
+	is := New(t)
+
+	is.RoundTrips(roundTripConfig{Name: "a", Ports: []int{80}}, json.Marshal, json.Unmarshal)
+	is.RoundTrips(&roundTripConfig{Name: "a"}, json.Marshal, json.Unmarshal)
+	is.RoundTrips(map[string]int{"a": 1}, json.Marshal, json.Unmarshal)
+
+	hit := 0
+	var msgs []string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	})
+	is.RoundTrips(roundTripConfig{Name: "a", Token: "secret"}, json.Marshal, json.Unmarshal)
+	is.RoundTrips(func() {}, json.Marshal, json.Unmarshal)
+	is.RoundTrips(1, json.Marshal, func([]byte, interface{}) error {
+		return errors.New("boom")
+	})
+	is.RoundTrips(nil, json.Marshal, json.Unmarshal)
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 4)
+	is.Equal(msgs[0], `value changed after a round trip through "{\"name\":\"a\",\"ports\":null}": objects of type 'is.roundTripConfig' differ at roundTripConfig.Token: got "", want "secret"`)
+	is.Equal(msgs[1], "failed to marshal 'func()': json: unsupported type: func()")
+	is.Equal(msgs[2], `failed to unmarshal 'int' from "1": boom`)
+	is.Equal(msgs[3], "expected a value to round-trip, but got nil")
+}
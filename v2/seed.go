@@ -0,0 +1,53 @@
+package is
+
+import (
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+)
+
+// seedEnv is the environment variable which overrides the seed chosen by
+// RandomSeed, to reproduce a failure.
+const seedEnv = "IS_SEED"
+
+// Seed returns a copy of this Is which prints seed on every failure, with
+// WithContext, and whose Rand method returns generators seeded with it.
+func (is *Is) Seed(seed int64) *Is {
+	newIs := is.WithContext("seed", seed)
+	newIs.seed = &seed
+	return newIs
+}
+
+// RandomSeed is like Seed, with a seed taken from the IS_SEED environment
+// variable if it is set, or from the current time otherwise. Setting
+// IS_SEED to the seed printed with a failure reproduces it:
+//
+//	is := is.New(t).RandomSeed()
+//	r := is.Rand()
+func (is *Is) RandomSeed() *Is {
+	is.TB.Helper()
+	seed := time.Now().UnixNano()
+	if s := os.Getenv(seedEnv); s != "" {
+		parsed, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			is.fail("invalid %s %q: %v", seedEnv, s, err)
+		} else {
+			seed = parsed
+		}
+	}
+	return is.Seed(seed)
+}
+
+// Rand returns a new random generator seeded with the seed set with Seed
+// or RandomSeed. Each call returns a generator producing the same sequence.
+// If no seed was set, the assertion fails and a generator seeded with 0 is
+// returned.
+func (is *Is) Rand() *rand.Rand {
+	is.TB.Helper()
+	if is.seed == nil {
+		is.fail("no seed to create a random generator, call Seed or RandomSeed first")
+		return rand.New(rand.NewSource(0))
+	}
+	return rand.New(rand.NewSource(*is.seed))
+}
@@ -0,0 +1,24 @@
+package is
+
+import (
+	"testing"
+)
+
+func TestSeed(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	seeded := c.Seed(42)
+	is.Equal(seeded.Rand().Int63(), seeded.Rand().Int63())
+	seeded.True(false)
+	c.Rand()
+	is.ErrMsg(c.Err(), "[True] expected boolean to be true\n\tseed: 42\n[Rand] no seed to create a random generator, call Seed or RandomSeed first")
+
+	t.Setenv(seedEnv, "7")
+	is.Equal(is.RandomSeed().Rand().Int63(), is.Seed(7).Rand().Int63())
+
+	t.Setenv(seedEnv, "x")
+	c = NewCollector()
+	c.RandomSeed()
+	is.ErrMsg(c.Err(), `[RandomSeed] invalid IS_SEED "x": strconv.ParseInt: parsing "x": invalid syntax`)
+}
@@ -0,0 +1,245 @@
+package is
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed semantic version, see https://semver.org
+type semver struct {
+	major, minor, patch uint64
+	pre                 []string
+
+	// parts is the number of components given, as minor and patch may be
+	// omitted in constraints
+	parts int
+}
+
+// parseSemver parses a semantic version with an optional "v" prefix. Build
+// metadata is accepted and ignored, as it does not affect precedence. If
+// partial is true, minor and patch may be omitted and default to 0, which is
+// used for versions inside constraints such as ">=1.2".
+func parseSemver(s string, partial bool) (semver, error) {
+	var v semver
+	str := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		str = str[:i]
+	}
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		if i == len(str)-1 {
+			return v, fmt.Errorf("invalid version %q: empty pre-release", s)
+		}
+		v.pre = strings.Split(str[i+1:], ".")
+		for _, id := range v.pre {
+			if id == "" {
+				return v, fmt.Errorf("invalid version %q: empty pre-release identifier", s)
+			}
+		}
+		str = str[:i]
+	}
+	parts := strings.Split(str, ".")
+	if len(parts) > 3 || (!partial && len(parts) != 3) {
+		return v, fmt.Errorf("invalid version %q: expected MAJOR.MINOR.PATCH", s)
+	}
+	v.parts = len(parts)
+	nums := []*uint64{&v.major, &v.minor, &v.patch}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid version %q: %q is not a number", s, p)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compare returns -1, 0 or 1 if v has lower, equal or higher precedence
+// than o.
+func (v semver) compare(o semver) int {
+	if c := compareUint(v.major, o.major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.minor, o.minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.patch, o.patch); c != 0 {
+		return c
+	}
+	// a version without pre-release has higher precedence
+	switch {
+	case len(v.pre) == 0 && len(o.pre) == 0:
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(o.pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		a, aErr := strconv.ParseUint(v.pre[i], 10, 64)
+		b, bErr := strconv.ParseUint(o.pre[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUint(a, b); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			// numeric identifiers have lower precedence
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(v.pre[i], o.pre[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(v.pre)), uint64(len(o.pre)))
+}
+
+// semverConstraintMatch checks whether v satisfies the provided constraint.
+//
+// A constraint is a list of alternatives separated by "||", each being a
+// list of comparisons separated by spaces or commas which must all hold.
+// Supported operators are =, !=, >, >=, <, <=, ~ (same major and minor, or
+// same major if only the major is given) and ^ (same major, or same minor
+// for 0.x versions if the minor is given). A version without an operator
+// must be equal.
+func semverConstraintMatch(v semver, constraint string) (bool, error) {
+	alts := [][]string{}
+	for _, alt := range strings.Split(constraint, "||") {
+		fields := strings.FieldsFunc(alt, func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		if len(fields) == 0 {
+			return false, fmt.Errorf("invalid constraint %q: empty alternative", constraint)
+		}
+		alts = append(alts, fields)
+	}
+	for _, fields := range alts {
+		all := true
+		for _, field := range fields {
+			ok, err := semverCompareMatch(v, field)
+			if err != nil {
+				return false, err
+			}
+			all = all && ok
+		}
+		if all {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func semverCompareMatch(v semver, field string) (bool, error) {
+	op := strings.TrimRight(field, "0123456789.-+abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	op = strings.TrimSuffix(op, "v")
+	c, err := parseSemver(field[len(op):], true)
+	if err != nil {
+		return false, err
+	}
+	cmp := v.compare(c)
+	switch op {
+	case "", "=", "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "~":
+		if cmp < 0 || v.major != c.major {
+			return false, nil
+		}
+		return c.parts == 1 || v.minor == c.minor, nil
+	case "^":
+		if cmp < 0 || v.major != c.major {
+			return false, nil
+		}
+		return c.major != 0 || c.parts == 1 || v.minor == c.minor, nil
+	}
+	return false, fmt.Errorf("invalid constraint %q: unknown operator %q", field, op)
+}
+
+// SemverEqual checks the provided semantic versions to determine if they have
+// the same precedence. Build metadata is ignored.
+func (is *Is) SemverEqual(actual string, expected string) bool {
+	is.TB.Helper()
+	a, err := parseSemver(actual, false)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	e, err := parseSemver(expected, false)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	if a.compare(e) != 0 {
+		is.fail("expected version %s to be equal to %s", actual, expected)
+		return false
+	}
+	return passed(is)
+}
+
+// SemverGreater checks the provided semantic versions to determine if a has
+// higher precedence than b.
+func (is *Is) SemverGreater(a string, b string) bool {
+	is.TB.Helper()
+	av, err := parseSemver(a, false)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	bv, err := parseSemver(b, false)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	if av.compare(bv) <= 0 {
+		is.fail("expected version %s to be greater than %s", a, b)
+		return false
+	}
+	return passed(is)
+}
+
+// SemverInRange checks the provided semantic version to determine if it
+// satisfies the constraint, such as ">=1.2.0 <2.0.0 || ^3.1".
+//
+// Alternatives are separated by "||" and each alternative is a list of
+// comparisons separated by spaces or commas. Supported operators are =, !=,
+// >, >=, <, <=, ~ and ^.
+func (is *Is) SemverInRange(v string, constraint string) bool {
+	is.TB.Helper()
+	sv, err := parseSemver(v, false)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	ok, err := semverConstraintMatch(sv, constraint)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	if !ok {
+		is.fail("expected version %s to satisfy constraint %q", v, constraint)
+		return false
+	}
+	return passed(is)
+}
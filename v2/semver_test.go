@@ -0,0 +1,84 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSemver(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.SemverEqual("v1.2.3", "1.2.3+build.5")
+	is.SemverGreater("1.10.0", "1.9.9")
+	is.SemverGreater("1.0.0", "1.0.0-rc.1")
+	is.SemverGreater("1.0.0-rc.10", "1.0.0-rc.2")
+	is.SemverGreater("1.0.0-beta", "1.0.0-alpha.1")
+	is.SemverGreater("1.0.0-alpha.1", "1.0.0-alpha")
+	is.SemverGreater("1.0.0-alpha.beta", "1.0.0-alpha.1")
+	is.SemverInRange("1.4.2", ">=1.2.0 <2.0.0")
+	is.SemverInRange("1.4.2", ">=1.2, <2")
+	is.SemverInRange("3.1.5", "<1 || ^3.1")
+	is.SemverInRange("1.2.9", "~1.2.3")
+	is.SemverInRange("0.2.5", "^0.2.3")
+	is.SemverInRange("1.2.3", "1.2.3")
+	is.SemverInRange("1.2.3", "!=1.2.4")
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	is.SemverEqual("1.2.3", "1.2.4")
+	is.SemverEqual("1.2", "1.2.0")
+	is.SemverGreater("1.0.0-rc.1", "1.0.0")
+	is.SemverGreater("1.0.0", "1.0.0")
+	is.SemverGreater("1.x.0", "1.0.0")
+	is.SemverInRange("2.0.0", ">=1.2.0 <2.0.0")
+	is.SemverInRange("1.3.0", "~1.2.3")
+	is.SemverInRange("0.3.0", "^0.2.3")
+	is.SemverInRange("1.0.0", "=>1.0.0")
+	is.SemverInRange("1.0.0", "1.0.0 ||")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 10)
+}
+
+func TestSemverTildeCaret(t *testing.T) {
+	is := New(t)
+
+	tests := []struct {
+		version    string
+		constraint string
+		match      bool
+	}{
+		{"1.0.0", "~1", true},
+		{"1.5.0", "~1", true},
+		{"2.0.0", "~1", false},
+		{"0.9.0", "~1", false},
+		{"1.2.0", "~1.2", true},
+		{"1.2.9", "~1.2", true},
+		{"1.3.0", "~1.2", false},
+		{"0.0.0", "^0", true},
+		{"0.5.0", "^0", true},
+		{"1.0.0", "^0", false},
+		{"0.0.5", "^0.0", true},
+		{"0.1.0", "^0.0", false},
+		{"1.0.0", "^1", true},
+		{"1.9.3", "^1", true},
+		{"2.0.0", "^1", false},
+		{"0.9.0", "^1", false},
+		{"1.5.0", "^1.2.3", true},
+		{"0.2.9", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+	}
+	for _, test := range tests {
+		v, err := parseSemver(test.version, false)
+		is.NotErr(err)
+		match, err := semverConstraintMatch(v, test.constraint)
+		is.NotErr(err)
+		is.Msg("%s %s", test.version, test.constraint).Equal(match, test.match)
+	}
+}
@@ -0,0 +1,57 @@
+package is
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+// SkipIf skips the test with the provided reason if cond is true.
+func (is *Is) SkipIf(cond bool, reason string) {
+	is.TB.Helper()
+	if cond {
+		is.TB.Skip(reason)
+	}
+}
+
+// SkipUnless skips the test with the provided reason if cond is false.
+func (is *Is) SkipUnless(cond bool, reason string) {
+	is.TB.Helper()
+	if !cond {
+		is.TB.Skip(reason)
+	}
+}
+
+// SkipUnlessEnv skips the test if the environment variable key is not set
+// to a non-empty value, for tests which need opting in, for example:
+//
+//	is.SkipUnlessEnv("INTEGRATION")
+func (is *Is) SkipUnlessEnv(key string) {
+	is.TB.Helper()
+	if os.Getenv(key) == "" {
+		is.TB.Skipf("%s is not set", key)
+	}
+}
+
+// OnOS calls fn with this Is only when running on the provided operating
+// system, as named by runtime.GOOS, keeping platform-specific expectations
+// next to the others:
+//
+//	is.OnOS("windows", func(is *is.Is) {
+//		is.Equal(path, `C:\data`)
+//	})
+func (is *Is) OnOS(goos string, fn func(is *Is)) {
+	is.TB.Helper()
+	if runtime.GOOS == goos {
+		fn(is)
+	}
+}
+
+// OnShort calls fn with this Is only when the -short flag is set, as
+// reported by testing.Short.
+func (is *Is) OnShort(fn func(is *Is)) {
+	is.TB.Helper()
+	if testing.Short() {
+		fn(is)
+	}
+}
@@ -0,0 +1,49 @@
+package is
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestSkip(t *testing.T) {
+	is := New(t)
+
+	skipped := func(f func(is *Is)) bool {
+		var s bool
+		t.Run("", func(t *testing.T) {
+			defer func() {
+				s = t.Skipped()
+			}()
+			f(New(t))
+		})
+		return s
+	}
+	const key = "IS_TEST_SKIP"
+	is.True(skipped(func(is *Is) { is.SkipIf(true, "x") }))
+	is.False(skipped(func(is *Is) { is.SkipIf(false, "x") }))
+	is.True(skipped(func(is *Is) { is.SkipUnless(false, "x") }))
+	is.False(skipped(func(is *Is) { is.SkipUnless(true, "x") }))
+	is.True(skipped(func(is *Is) { is.SkipUnlessEnv(key) }))
+	t.Setenv(key, "1")
+	is.False(skipped(func(is *Is) { is.SkipUnlessEnv(key) }))
+}
+
+func TestOnOS(t *testing.T) {
+	is := New(t)
+
+	var ran []string
+	is.OnOS(runtime.GOOS, func(is *Is) {
+		ran = append(ran, "current")
+	})
+	is.OnOS("no-such-os", func(is *Is) {
+		ran = append(ran, "other")
+	})
+	is.OnShort(func(is *Is) {
+		ran = append(ran, "short")
+	})
+	expected := []string{"current"}
+	if testing.Short() {
+		expected = append(expected, "short")
+	}
+	is.Equal(ran, expected)
+}
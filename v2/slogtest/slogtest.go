@@ -0,0 +1,216 @@
+//go:build go1.21
+
+// Package slogtest provides a slog.Handler recording log records, with
+// assertions on them reported through github.com/ilius/is/v2.
+//
+// It is a separate package as log/slog requires Go 1.21, while
+// github.com/ilius/is/v2 supports older versions.
+package slogtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ilius/is/v2"
+)
+
+// slogEntry is a recorded slog.Record, with the attributes of the record
+// and of its handler flattened. Attributes in groups have dotted keys.
+type slogEntry struct {
+	level slog.Level
+	msg   string
+	attrs []slog.Attr
+}
+
+func (e slogEntry) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q", e.level, e.msg)
+	for _, a := range e.attrs {
+		fmt.Fprintf(&b, " %s=%v", a.Key, a.Value)
+	}
+	return b.String()
+}
+
+// attr returns the value of the attribute with the provided key.
+func (e slogEntry) attr(key string) (slog.Value, bool) {
+	for _, a := range e.attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+// matches reports whether e has the provided level, a message containing
+// msgSubstr, and each of the flattened attrs.
+func (e slogEntry) matches(level slog.Level, msgSubstr string, attrs []slog.Attr) bool {
+	if e.level != level || !strings.Contains(e.msg, msgSubstr) {
+		return false
+	}
+	for _, a := range attrs {
+		v, ok := e.attr(a.Key)
+		if !ok || !reflect.DeepEqual(v.Any(), a.Value.Any()) {
+			return false
+		}
+	}
+	return true
+}
+
+// slogStore holds the records of a Recorder and the handlers derived
+// from it.
+type slogStore struct {
+	mu      sync.Mutex
+	entries []slogEntry
+}
+
+// Recorder is a slog.Handler which records every log record, with
+// assertions on the recorded records. Handlers derived from it with
+// WithAttrs and WithGroup record to the same list.
+type Recorder struct {
+	is     *is.Is
+	store  *slogStore
+	attrs  []slog.Attr
+	prefix string
+}
+
+// NewRecorder creates a new Recorder with no records, whose assertions
+// are reported by a.
+//
+// For example:
+//
+//	logs := slogtest.NewRecorder(is.New(t))
+//	svc := NewService(slog.New(logs))
+//	svc.Start()
+//	logs.HasRecord(slog.LevelInfo, "started", slog.Int("port", 80))
+func NewRecorder(a *is.Is) *Recorder {
+	return &Recorder{is: a, store: &slogStore{}}
+}
+
+// flattenAttr appends a to attrs, resolved, with the keys of the
+// attributes of groups prefixed by the group name.
+func flattenAttr(attrs []slog.Attr, prefix string, a slog.Attr) []slog.Attr {
+	v := a.Value.Resolve()
+	if v.Kind() != slog.KindGroup {
+		if a.Key == "" {
+			return attrs
+		}
+		return append(attrs, slog.Attr{Key: prefix + a.Key, Value: v})
+	}
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	for _, ga := range v.Group() {
+		attrs = flattenAttr(attrs, prefix, ga)
+	}
+	return attrs
+}
+
+// Enabled reports true for every level.
+func (r *Recorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle records the provided record.
+func (r *Recorder) Handle(_ context.Context, record slog.Record) error {
+	e := slogEntry{
+		level: record.Level,
+		msg:   record.Message,
+		attrs: append([]slog.Attr(nil), r.attrs...),
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		e.attrs = flattenAttr(e.attrs, r.prefix, a)
+		return true
+	})
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.entries = append(r.store.entries, e)
+	return nil
+}
+
+// WithAttrs returns a handler recording to the same list, which adds attrs
+// to every record.
+func (r *Recorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newR := *r
+	newR.attrs = append([]slog.Attr(nil), r.attrs...)
+	for _, a := range attrs {
+		newR.attrs = flattenAttr(newR.attrs, r.prefix, a)
+	}
+	return &newR
+}
+
+// WithGroup returns a handler recording to the same list, which qualifies
+// the keys of the attributes added later with name.
+func (r *Recorder) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return r
+	}
+	newR := *r
+	newR.prefix = r.prefix + name + "."
+	return &newR
+}
+
+func (r *Recorder) entries() []slogEntry {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return append([]slogEntry(nil), r.store.entries...)
+}
+
+// Len returns the number of records recorded.
+func (r *Recorder) Len() int {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return len(r.store.entries)
+}
+
+// Reset drops all the records recorded so far.
+func (r *Recorder) Reset() {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.entries = nil
+}
+
+// formatSlogEntries formats records one per line for failure messages.
+func formatSlogEntries(entries []slogEntry) string {
+	if len(entries) == 0 {
+		return " none"
+	}
+	var b strings.Builder
+	for i, e := range entries {
+		fmt.Fprintf(&b, "\n\t[%d] %s", i, e)
+	}
+	return b.String()
+}
+
+// HasRecord checks the recorded records to determine if one of them has
+// the provided level, a message containing msgSubstr, and each of attrs.
+// The keys of attributes in groups are dotted, like "req.method", and
+// values are compared with reflect.DeepEqual. All the records are shown on
+// failure.
+func (r *Recorder) HasRecord(level slog.Level, msgSubstr string, attrs ...slog.Attr) bool {
+	r.is.TB.Helper()
+	var want []slog.Attr
+	for _, a := range attrs {
+		want = flattenAttr(want, "", a)
+	}
+	entries := r.entries()
+	for _, e := range entries {
+		if e.matches(level, msgSubstr, want) {
+			return r.is.Check(true, "")
+		}
+	}
+	expected := slogEntry{level: level, msg: msgSubstr, attrs: want}
+	return r.is.Check(false, "expected a record like %s, but got:%s", expected, formatSlogEntries(entries))
+}
+
+// RecordCount checks the number of recorded records.
+func (r *Recorder) RecordCount(n int) bool {
+	r.is.TB.Helper()
+	if entries := r.entries(); len(entries) != n {
+		return r.is.Check(false, "expected %d records, but got %d:%s", n, len(entries), formatSlogEntries(entries))
+	}
+	return r.is.Check(true, "")
+}
@@ -0,0 +1,42 @@
+//go:build go1.21
+
+package slogtest
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/ilius/is/v2"
+)
+
+func TestRecorder(t *testing.T) {
+	check := is.New(t)
+
+	logs := NewRecorder(check)
+	logger := slog.New(logs)
+	logger.Info("server started", "port", 80)
+	logger.With("req", "r1").WithGroup("http").Warn("slow request", slog.Group("timing", "ms", 1500), "path", "/")
+	logger.Debug("tick")
+
+	check.Equal(logs.Len(), 3)
+	logs.HasRecord(slog.LevelInfo, "started")
+	logs.HasRecord(slog.LevelInfo, "server started", slog.Int("port", 80))
+	logs.HasRecord(slog.LevelWarn, "slow", slog.String("req", "r1"), slog.Int64("http.timing.ms", 1500))
+	logs.HasRecord(slog.LevelWarn, "", slog.Group("http", "path", "/"))
+	logs.RecordCount(3)
+
+	c := is.NewCollector()
+	logs.is = c.Is
+	check.False(logs.HasRecord(slog.LevelError, "started"))
+	check.ErrMsg(c.Err(), `[HasRecord] expected a record like ERROR "started", but got:
+	[0] INFO "server started" port=80
+	[1] WARN "slow request" req=r1 http.timing.ms=1500 http.path=/
+	[2] DEBUG "tick"`)
+	check.False(logs.HasRecord(slog.LevelInfo, "started", slog.Int("port", 81)))
+	check.False(logs.RecordCount(2))
+	logs.Reset()
+	check.False(logs.RecordCount(1))
+	errs := c.Errs()
+	check.Len(errs, 4)
+	check.ErrMsg(errs[3], "[RecordCount] expected 1 records, but got 0: none")
+}
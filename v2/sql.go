@@ -0,0 +1,118 @@
+package is
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sqlTimeout is the timeout of the queries run by the SQL assertions.
+const sqlTimeout = 30 * time.Second
+
+// SQLQueryer is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type SQLQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryRows runs the query and returns the scanned values of every row.
+func queryRows(db SQLQueryer, query string, args []interface{}) ([][]interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sqlTimeout)
+	defer cancel()
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var result [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			// Drivers may reuse the memory of []byte values.
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result = append(result, values)
+	}
+	return result, rows.Err()
+}
+
+// formatQuery formats a query and its arguments for failure messages.
+func formatQuery(query string, args []interface{}) string {
+	if len(args) == 0 {
+		return fmt.Sprintf("%q", query)
+	}
+	return fmt.Sprintf("%q with args %s", query, formatValue(args))
+}
+
+// QueryRowEqual runs the query with args, and checks that it returns at
+// least one row, and that the values of the first row are equal to
+// expected. Values are compared like with Equal, so an int64 column is
+// equal to an int, and a []byte column is compared as a string.
+func (is *Is) QueryRowEqual(db SQLQueryer, query string, args []interface{}, expected ...interface{}) bool {
+	is.TB.Helper()
+	rows, err := queryRows(db, query, args)
+	if err != nil {
+		is.fail("query %s failed: %v", formatQuery(query, args), err)
+		return false
+	}
+	if len(rows) == 0 {
+		is.fail("expected query %s to return a row, but got no rows", formatQuery(query, args))
+		return false
+	}
+	row := rows[0]
+	equal := len(row) == len(expected)
+	for i := 0; equal && i < len(row); i++ {
+		equal = isEqual(row[i], expected[i], is.equalOpts)
+	}
+	if !equal {
+		is.fail("expected query %s to return %s, but got %s",
+			formatQuery(query, args), is.formatValue(expected), is.formatValue(row))
+		return false
+	}
+	return passed(is)
+}
+
+// RowCount runs the query with args, and checks that it returns n rows.
+func (is *Is) RowCount(db SQLQueryer, query string, n int, args ...interface{}) bool {
+	is.TB.Helper()
+	rows, err := queryRows(db, query, args)
+	if err != nil {
+		is.fail("query %s failed: %v", formatQuery(query, args), err)
+		return false
+	}
+	if len(rows) != n {
+		is.fail("expected query %s to return %d rows, but got %d: %s",
+			formatQuery(query, args), n, len(rows), is.formatValue(rows))
+		return false
+	}
+	return passed(is)
+}
+
+// NoRows runs the query with args, and checks that it returns no rows.
+func (is *Is) NoRows(db SQLQueryer, query string, args ...interface{}) bool {
+	is.TB.Helper()
+	rows, err := queryRows(db, query, args)
+	if err != nil {
+		is.fail("query %s failed: %v", formatQuery(query, args), err)
+		return false
+	}
+	if len(rows) != 0 {
+		is.fail("expected query %s to return no rows, but got %d: %s",
+			formatQuery(query, args), len(rows), is.formatValue(rows))
+		return false
+	}
+	return passed(is)
+}
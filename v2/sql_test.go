@@ -0,0 +1,104 @@
+package is
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeDriver serves fixed tables: the query is the table name.
+type fakeDriver struct{}
+
+type fakeConn struct{}
+
+type fakeStmt struct {
+	query string
+}
+
+type fakeRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+var fakeTables = map[string]*fakeRows{
+	"users": {
+		cols: []string{"id", "name"},
+		rows: [][]driver.Value{{int64(1), []byte("bob")}, {int64(2), []byte("alice")}},
+	},
+	"empty": {cols: []string{"id"}},
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	t, ok := fakeTables[strings.TrimSpace(s.query)]
+	if !ok {
+		return nil, fmt.Errorf("no such table: %s", s.query)
+	}
+	rows := *t
+	return &rows, nil
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("is-fake", fakeDriver{})
+}
+
+func TestSQL(t *testing.T) {
+	is := New(t)
+
+	db, err := sql.Open("is-fake", "")
+	is.NotErr(err)
+	defer db.Close()
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.QueryRowEqual(db, "users", nil, 1, "bob")
+	is.RowCount(db, "users", 2)
+	is.NoRows(db, "empty")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.QueryRowEqual(db, "users", []interface{}{7}, 2, "bob")
+	is.Strict().Equal(msg, `expected query "users" with args [7] to return [2 bob], but got [1 bob]`)
+	is.QueryRowEqual(db, "users", nil, 1)
+	is.QueryRowEqual(db, "empty", nil, 1)
+	is.RowCount(db, "users", 1)
+	is.Strict().Equal(msg, `expected query "users" to return 1 rows, but got 2: [[1 bob] [2 alice]]`)
+	is.NoRows(db, "users")
+	is.NoRows(db, "missing")
+	is.Strict().Equal(msg, `query "missing" failed: no such table: missing`)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 6)
+}
@@ -0,0 +1,151 @@
+package is
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxExcerptLen is the number of runes shown from long strings in failure
+// messages, in addition to the length of the relevant prefix or suffix.
+const maxExcerptLen = 20
+
+// head returns the first n runes of s, followed by "..." if s is longer.
+func head(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "..."
+}
+
+// tail returns the last n runes of s, preceded by "..." if s is longer.
+func tail(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return "..." + string(r[len(r)-n:])
+}
+
+// HasPrefix checks the provided string to determine if it begins with prefix.
+func (is *Is) HasPrefix(s string, prefix string) bool {
+	is.TB.Helper()
+	if !strings.HasPrefix(s, prefix) {
+		is.fail("expected string to have prefix %q, but it starts with: %q",
+			prefix, head(s, len([]rune(prefix))+maxExcerptLen))
+		return false
+	}
+	return passed(is)
+}
+
+// NotHasPrefix checks the provided string to determine if it does not begin
+// with prefix.
+func (is *Is) NotHasPrefix(s string, prefix string) bool {
+	is.TB.Helper()
+	if strings.HasPrefix(s, prefix) {
+		is.fail("expected string not to have prefix %q, but it starts with: %q",
+			prefix, head(s, len([]rune(prefix))+maxExcerptLen))
+		return false
+	}
+	return passed(is)
+}
+
+// HasSuffix checks the provided string to determine if it ends with suffix.
+func (is *Is) HasSuffix(s string, suffix string) bool {
+	is.TB.Helper()
+	if !strings.HasSuffix(s, suffix) {
+		is.fail("expected string to have suffix %q, but it ends with: %q",
+			suffix, tail(s, len([]rune(suffix))+maxExcerptLen))
+		return false
+	}
+	return passed(is)
+}
+
+// NotHasSuffix checks the provided string to determine if it does not end
+// with suffix.
+func (is *Is) NotHasSuffix(s string, suffix string) bool {
+	is.TB.Helper()
+	if strings.HasSuffix(s, suffix) {
+		is.fail("expected string not to have suffix %q, but it ends with: %q",
+			suffix, tail(s, len([]rune(suffix))+maxExcerptLen))
+		return false
+	}
+	return passed(is)
+}
+
+// EqualFold checks the provided strings to determine if they are equal under
+// Unicode case-folding, which is a more general form of case-insensitivity.
+func (is *Is) EqualFold(actual string, expected string) bool {
+	is.TB.Helper()
+	if !strings.EqualFold(actual, expected) {
+		is.fail("got %q. expected %q (case-insensitive)", actual, expected)
+		return false
+	}
+	return passed(is)
+}
+
+// normalizeLineEndings converts "\r\n" and "\r" line endings to "\n".
+func normalizeLineEndings(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+// collapseWhitespace trims s and replaces each run of whitespace, including
+// line endings, with a single space.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// EqualTrimmed checks the provided strings to determine if they are equal
+// after removing leading and trailing whitespace.
+func (is *Is) EqualTrimmed(actual string, expected string) bool {
+	is.TB.Helper()
+	a, e := strings.TrimSpace(actual), strings.TrimSpace(expected)
+	if a != e {
+		is.fail("got %q. expected %q (trimmed)", a, e)
+		return false
+	}
+	return passed(is)
+}
+
+// EqualIgnoringLineEndings checks the provided strings to determine if they
+// are equal after converting "\r\n" and "\r" line endings to "\n". This is
+// useful for rendered text that must compare equal on Windows and Linux.
+func (is *Is) EqualIgnoringLineEndings(actual string, expected string) bool {
+	is.TB.Helper()
+	a, e := normalizeLineEndings(actual), normalizeLineEndings(expected)
+	if a != e {
+		is.fail("got %q. expected %q (ignoring line endings)", a, e)
+		return false
+	}
+	return passed(is)
+}
+
+// EqualIgnoringWhitespace checks the provided strings to determine if they
+// are equal after trimming them and collapsing each run of whitespace,
+// including line endings, into a single space.
+func (is *Is) EqualIgnoringWhitespace(actual string, expected string) bool {
+	is.TB.Helper()
+	a, e := collapseWhitespace(actual), collapseWhitespace(expected)
+	if a != e {
+		is.fail("got %q. expected %q (ignoring whitespace)", a, e)
+		return false
+	}
+	return passed(is)
+}
+
+// Matches checks the provided string to determine if it matches the regular
+// expression pattern.
+func (is *Is) Matches(s string, pattern string) bool {
+	is.TB.Helper()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		is.fail("invalid pattern %q: %v", pattern, err)
+		return false
+	}
+	if !re.MatchString(s) {
+		is.fail("expected %q to match %q", s, pattern)
+		return false
+	}
+	return passed(is)
+}
@@ -0,0 +1,82 @@
+package is
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStrings(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.HasPrefix("hello world", "hello")
+	is.HasPrefix("hello", "")
+	is.NotHasPrefix("hello world", "world")
+	is.HasSuffix("hello world", "world")
+	is.NotHasSuffix("hello world", "hello")
+	is.EqualFold("Hello World", "hELLO wORLD")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	long := strings.Repeat("abcdefghij", 10)
+	is.HasPrefix(long, "xyz")
+	is.Equal(msg, `expected string to have prefix "xyz", but it starts with: "abcdefghijabcdefghijabc..."`)
+	is.HasSuffix(long, "xyz")
+	is.Equal(msg, `expected string to have suffix "xyz", but it ends with: "...hijabcdefghijabcdefghij"`)
+	is.NotHasPrefix(long, "abc")
+	is.NotHasSuffix(long, "hij")
+	is.Equal(msg, `expected string not to have suffix "hij", but it ends with: "...hijabcdefghijabcdefghij"`)
+	is.EqualFold("hello", "world")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 5)
+}
+
+func TestStringsNormalized(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.EqualTrimmed("  hello\n", "hello")
+	is.EqualIgnoringLineEndings("a\r\nb\rc\n", "a\nb\nc\n")
+	is.EqualIgnoringWhitespace(" a  b\r\n\tc ", "a b c")
+
+	hit := 0
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+	})
+	is.EqualTrimmed("a b", "ab")
+	is.EqualIgnoringLineEndings("a\n\nb", "a\nb")
+	is.EqualIgnoringWhitespace("ab", "a b")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 3)
+}
+
+func TestMatches(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Matches("v1.2.3", `^v\d+\.\d+\.\d+$`)
+	is.Matches("v1.2", `^v\d+\.\d+\.\d+$`)
+	is.Equal(msg, `expected "v1.2" to match "^v\\d+\\.\\d+\\.\\d+$"`)
+	is.Matches("x", "(")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+}
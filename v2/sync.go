@@ -0,0 +1,103 @@
+package is
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// syncPollInterval is how often MutexUnlockedWithin tries the lock.
+const syncPollInterval = time.Millisecond
+
+// goroutineDump returns the stacks of all goroutines, for failure messages
+// of assertions on synchronization primitives.
+func goroutineDump() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// WaitGroupDoneWithin waits up to timeout for the provided WaitGroup
+// counter to reach zero, and fails with a dump of all goroutines if it does
+// not. The goroutine waiting on the WaitGroup is left running after a
+// timeout.
+func (is *Is) WaitGroupDoneWithin(wg *sync.WaitGroup, timeout time.Duration) bool {
+	is.TB.Helper()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return passed(is)
+	case <-timer.C:
+		is.fail("expected WaitGroup to be done within %v, goroutines:\n%s", timeout, goroutineDump())
+		return false
+	}
+}
+
+// TryLocker is implemented by *sync.Mutex and *sync.RWMutex.
+type TryLocker interface {
+	TryLock() bool
+	Unlock()
+}
+
+// MutexUnlockedWithin waits up to timeout for the provided mutex to be
+// unlocked, and fails with a dump of all goroutines if it is not. The mutex
+// is briefly locked to check it.
+func (is *Is) MutexUnlockedWithin(mu TryLocker, timeout time.Duration) bool {
+	is.TB.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		if mu.TryLock() {
+			mu.Unlock()
+			return passed(is)
+		}
+		if time.Now().After(deadline) {
+			is.fail("expected mutex to be unlocked within %v, goroutines:\n%s", timeout, goroutineDump())
+			return false
+		}
+		time.Sleep(syncPollInterval)
+	}
+}
+
+// NoRace runs fn twice concurrently, starting both runs at the same time,
+// and fails if either of them panics. This is the minimal pattern for
+// smoking out data races in small units, and it is only meaningful when
+// the tests are run with the -race flag, which reports the races found.
+func (is *Is) NoRace(fn func()) bool {
+	is.TB.Helper()
+	type result struct {
+		value    interface{}
+		stack    string
+		panicked bool
+	}
+	var results [2]result
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range results {
+		wg.Add(1)
+		go func(r *result) {
+			defer wg.Done()
+			<-start
+			r.value, r.stack, r.panicked = catchPanic(fn)
+		}(&results[i])
+	}
+	close(start)
+	wg.Wait()
+	for i, r := range results {
+		if r.panicked {
+			is.fail("expected concurrent run %d not to panic, but it panicked with %v at:\n%s", i+1, r.value, r.stack)
+			return false
+		}
+	}
+	return passed(is)
+}
@@ -0,0 +1,78 @@
+package is
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncWithin(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		wg.Done()
+	}()
+	is.WaitGroupDoneWithin(&wg, time.Second)
+
+	var mu sync.Mutex
+	mu.Lock()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		mu.Unlock()
+	}()
+	is.MutexUnlockedWithin(&mu, time.Second)
+	var rw sync.RWMutex
+	is.MutexUnlockedWithin(&rw, time.Second)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	var stuck sync.WaitGroup
+	stuck.Add(1)
+	defer stuck.Done()
+	is.WaitGroupDoneWithin(&stuck, 10*time.Millisecond)
+	is.Strict().True(strings.HasPrefix(msg, "expected WaitGroup to be done within 10ms, goroutines:\ngoroutine "))
+	mu.Lock()
+	defer mu.Unlock()
+	is.MutexUnlockedWithin(&mu, 10*time.Millisecond)
+	is.Strict().True(strings.Contains(msg, "TestSyncWithin"))
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 2)
+}
+
+func TestNoRace(t *testing.T) {
+	is := New(t)
+
+	var mu sync.Mutex
+	n := 0
+	is.NoRace(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		n++
+	})
+	is.Equal(n, 2)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.NoRace(func() { panic("boom") })
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 1)
+	is.True(strings.HasPrefix(msg, "expected concurrent run 1 not to panic, but it panicked with boom at:\n"))
+}
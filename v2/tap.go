@@ -0,0 +1,123 @@
+package is
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// tapEnv is the environment variable which enables TAP output for every Is
+// created with New. Its value is the path of the file to write to, or "-"
+// for the standard output.
+const tapEnv = "IS_TAP"
+
+// TAPWriter writes the outcome of assertions in the Test Anything Protocol
+// format, one "ok" or "not ok" line per assertion, so they can be consumed
+// by TAP-aware CI dashboards. It is safe for concurrent use.
+type TAPWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	n      int
+	closed bool
+}
+
+// NewTAPWriter creates a new TAPWriter which writes to w, starting with the
+// TAP version line.
+func NewTAPWriter(w io.Writer) *TAPWriter {
+	fmt.Fprintln(w, "TAP version 13")
+	return &TAPWriter{w: w}
+}
+
+// TAP returns a copy of this Is which reports the outcome of every
+// assertion to w, in addition to the testing object. Passing nil disables
+// TAP output.
+func (is *Is) TAP(w *TAPWriter) *Is {
+	newIs := *is
+	newIs.tap = w
+	return &newIs
+}
+
+// Close writes the trailing plan line with the number of assertions
+// reported. Nothing is written after Close.
+func (t *TAPWriter) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	_, err := fmt.Fprintf(t.w, "1..%d\n", t.n)
+	if t.closer != nil {
+		if cerr := t.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// report writes the outcome of the named assertion being run, with the
+// failure message as TAP diagnostic lines if it failed.
+func (t *TAPWriter) report(is *Is, assertion string, ok bool, msg string) {
+	desc := assertion
+	if name := is.TB.Name(); name != "" {
+		desc = name + ": " + desc
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.n++
+	if ok {
+		fmt.Fprintf(t.w, "ok %d - %s\n", t.n, desc)
+		return
+	}
+	if is.warn {
+		// Failures of TODO tests do not count as failures.
+		desc += " # TODO warning"
+	}
+	fmt.Fprintf(t.w, "not ok %d - %s\n", t.n, desc)
+	for _, line := range strings.Split(msg, "\n") {
+		fmt.Fprintf(t.w, "# %s\n", line)
+	}
+}
+
+var (
+	envTAPOnce   sync.Once
+	envTAPWriter *TAPWriter
+)
+
+// EnvTAPWriter returns the TAPWriter used by every Is created with New when
+// the IS_TAP environment variable is set, or nil if it is not set. Its
+// value is the path of the file to write to, or "-" for the standard
+// output. Close it from TestMain to write the plan line:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		if w := is.EnvTAPWriter(); w != nil {
+//			w.Close()
+//		}
+//		os.Exit(code)
+//	}
+func EnvTAPWriter() *TAPWriter {
+	envTAPOnce.Do(func() {
+		path := os.Getenv(tapEnv)
+		switch path {
+		case "":
+		case "-":
+			envTAPWriter = NewTAPWriter(os.Stdout)
+		default:
+			f, err := os.Create(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "is: TAP output disabled: %v\n", err)
+				return
+			}
+			envTAPWriter = NewTAPWriter(f)
+			envTAPWriter.closer = f
+		}
+	})
+	return envTAPWriter
+}
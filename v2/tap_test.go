@@ -0,0 +1,44 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestTAP(t *testing.T) {
+	is := New(t)
+
+	var buf bytes.Buffer
+	w := NewTAPWriter(&buf)
+	tap := is.Lax().TAP(w)
+
+	hit := 0
+	tap = tap.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		name, _ := assertionFrame()
+		is.tap.report(is, name, false, fmt.Sprintf(format, args...))
+	})
+	tap.Equal(1, 1)
+	tap.Msg("id %d", 7).True(false)
+	tap.Warn().True(false)
+	tap.ErrMsg(fmt.Errorf("a"), "a")
+	OneOfT(tap, 1, 1, 2)
+	tap.ShouldPanic(func() { panic("x") })
+
+	is.NotErr(w.Close())
+	is.NotErr(w.Close())
+	tap.Equal(1, 1)
+	is.Equal(hit, 2)
+	is.Equal(buf.String(), `TAP version 13
+ok 1 - TestTAP: Equal
+not ok 2 - TestTAP: True
+# expected boolean to be true
+not ok 3 - TestTAP: True # TODO warning
+# expected boolean to be true
+ok 4 - TestTAP: ErrMsg
+ok 5 - TestTAP: OneOfT
+ok 6 - TestTAP: ShouldPanic
+1..6
+`)
+}
@@ -0,0 +1,17 @@
+package is
+
+import (
+	"time"
+)
+
+// WithinDuration checks the provided times to determine if actual is
+// within delta of expected.
+func (is *Is) WithinDuration(actual time.Time, expected time.Time, delta time.Duration) bool {
+	is.TB.Helper()
+	d := actual.Sub(expected)
+	if d < -delta || d > delta {
+		is.fail("expected %v to be within %v of %v, but the difference is %v", actual, delta, expected, d)
+		return false
+	}
+	return passed(is)
+}
@@ -0,0 +1,26 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWithinDuration(t *testing.T) {
+	is := New(t)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	is.WithinDuration(base.Add(time.Second), base, time.Second)
+	is.WithinDuration(base.Add(-time.Second), base, time.Second)
+	is.WithinDuration(base.Add(2*time.Second), base, time.Second)
+	is.Equal(msg, "expected 2020-01-01 00:00:02 +0000 UTC to be within 1s of 2020-01-01 00:00:00 +0000 UTC, but the difference is 2s")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 1)
+}
@@ -0,0 +1,69 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// typeAndKind formats a type and its kind for failure messages.
+func typeAndKind(t reflect.Type) string {
+	if t == nil {
+		return "'<nil>'"
+	}
+	return fmt.Sprintf("'%s' (kind %s)", t, t.Kind())
+}
+
+// targetType returns the type a target argument stands for: a reflect.Type
+// is used as is, a nil pointer to an interface, such as (*io.Reader)(nil),
+// stands for the interface, and any other value stands for its type.
+func targetType(target interface{}) reflect.Type {
+	if t, ok := target.(reflect.Type); ok {
+		return t
+	}
+	t := reflect.TypeOf(target)
+	if t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface && reflect.ValueOf(target).IsNil() {
+		return t.Elem()
+	}
+	return t
+}
+
+// Kind checks the provided object to determine if its kind is expected.
+func (is *Is) Kind(expected reflect.Kind, o interface{}) bool {
+	is.TB.Helper()
+	if o == nil {
+		is.fail("expected object of kind %s, but got nil", expected)
+		return false
+	}
+	if t := reflect.TypeOf(o); t.Kind() != expected {
+		is.fail("expected object of kind %s, but got %s", expected, typeAndKind(t))
+		return false
+	}
+	return passed(is)
+}
+
+// AssignableTo checks the provided object to determine if it can be
+// assigned to a variable of the target type. target may be a reflect.Type,
+// a nil pointer to an interface, such as (*io.Reader)(nil), or a value of
+// the target type.
+func (is *Is) AssignableTo(o interface{}, target interface{}) bool {
+	is.TB.Helper()
+	t, tt := reflect.TypeOf(o), targetType(target)
+	if t == nil || tt == nil || !t.AssignableTo(tt) {
+		is.fail("expected object of type %s to be assignable to %s", typeAndKind(t), typeAndKind(tt))
+		return false
+	}
+	return passed(is)
+}
+
+// ConvertibleTo checks the provided object to determine if it can be
+// converted to the target type. target is interpreted like with
+// AssignableTo.
+func (is *Is) ConvertibleTo(o interface{}, target interface{}) bool {
+	is.TB.Helper()
+	t, tt := reflect.TypeOf(o), targetType(target)
+	if t == nil || tt == nil || !t.ConvertibleTo(tt) {
+		is.fail("expected object of type %s to be convertible to %s", typeAndKind(t), typeAndKind(tt))
+		return false
+	}
+	return passed(is)
+}
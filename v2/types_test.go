@@ -0,0 +1,45 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypes(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.Kind(reflect.Int64, time.Second)
+	is.Kind(reflect.Ptr, &bytes.Buffer{})
+	is.AssignableTo(&bytes.Buffer{}, (*io.Reader)(nil))
+	is.AssignableTo(1, 2)
+	is.AssignableTo(time.Second, reflect.TypeOf(time.Duration(0)))
+	is.ConvertibleTo(1, time.Duration(0))
+	is.ConvertibleTo("a", []byte(nil))
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.Kind(reflect.Int, time.Second)
+	is.Strict().Equal(msg, "expected object of kind int, but got 'time.Duration' (kind int64)")
+	is.Kind(reflect.Int, nil)
+	is.AssignableTo(1, time.Duration(0))
+	is.Strict().Equal(msg, "expected object of type 'int' (kind int) to be assignable to 'time.Duration' (kind int64)")
+	is.AssignableTo(bytes.Buffer{}, (*io.Reader)(nil))
+	is.Strict().Equal(msg, "expected object of type 'bytes.Buffer' (kind struct) to be assignable to 'io.Reader' (kind interface)")
+	is.AssignableTo(nil, 1)
+	is.ConvertibleTo("a", 1.5)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 6)
+}
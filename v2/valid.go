@@ -0,0 +1,164 @@
+package is
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// checkUUID returns an error describing why s is not a UUID in its canonical
+// textual form (8-4-4-4-12 hexadecimal digits), or nil if it is one.
+func checkUUID(s string) error {
+	if len(s) != 36 {
+		return fmt.Errorf("length is %d, expected 36", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return fmt.Errorf("expected '-' at position %d, got %q", i, c)
+			}
+			continue
+		}
+		if !strings.ContainsRune("0123456789abcdefABCDEF", rune(c)) {
+			return fmt.Errorf("invalid hex character %q at position %d", c, i)
+		}
+	}
+	return nil
+}
+
+// checkEmail returns an error describing why s is not a bare email address
+// (without display name), or nil if it is one.
+func checkEmail(s string) error {
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return errors.New("missing '@'")
+	}
+	if at == 0 {
+		return errors.New("local part before '@' is empty")
+	}
+	if at == len(s)-1 {
+		return errors.New("domain part after '@' is empty")
+	}
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return err
+	}
+	if addr.Address != s {
+		return errors.New("contains a display name or extra characters")
+	}
+	return nil
+}
+
+// checkURL returns an error describing why s is not an absolute URL with a
+// scheme and a host, or nil if it is one.
+func checkURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" {
+		return errors.New("missing scheme")
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return nil
+}
+
+// checkIP returns an error describing why s is not an IPv4 or IPv6 address,
+// or nil if it is one.
+func checkIP(s string) error {
+	if net.ParseIP(s) != nil {
+		return nil
+	}
+	if strings.Contains(s, ":") {
+		return errors.New("invalid IPv6 address")
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 4 {
+		return fmt.Errorf("expected 4 dot-separated octets, got %d", len(parts))
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || n > 255 {
+			return fmt.Errorf("octet %d (%q) is not a number between 0 and 255", i+1, p)
+		}
+	}
+	return errors.New("invalid IPv4 address")
+}
+
+// checkJSON returns an error describing why s is not valid JSON, or nil if
+// it is valid.
+func checkJSON(s string) error {
+	var v interface{}
+	err := json.Unmarshal([]byte(s), &v)
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("%v (at offset %d)", err, syntaxErr.Offset)
+	}
+	return err
+}
+
+// ValidUUID checks the provided string to determine if it is a UUID in its
+// canonical textual form, such as "123e4567-e89b-12d3-a456-426614174000".
+func (is *Is) ValidUUID(s string) bool {
+	is.TB.Helper()
+	if err := checkUUID(s); err != nil {
+		is.fail("expected %q to be a valid UUID: %v", s, err)
+		return false
+	}
+	return passed(is)
+}
+
+// ValidEmail checks the provided string to determine if it is a bare email
+// address, such as "user@example.com".
+func (is *Is) ValidEmail(s string) bool {
+	is.TB.Helper()
+	if err := checkEmail(s); err != nil {
+		is.fail("expected %q to be a valid email address: %v", s, err)
+		return false
+	}
+	return passed(is)
+}
+
+// ValidURL checks the provided string to determine if it is an absolute URL
+// with both a scheme and a host.
+func (is *Is) ValidURL(s string) bool {
+	is.TB.Helper()
+	if err := checkURL(s); err != nil {
+		is.fail("expected %q to be a valid URL: %v", s, err)
+		return false
+	}
+	return passed(is)
+}
+
+// ValidIP checks the provided string to determine if it is an IPv4 or IPv6
+// address.
+func (is *Is) ValidIP(s string) bool {
+	is.TB.Helper()
+	if err := checkIP(s); err != nil {
+		is.fail("expected %q to be a valid IP address: %v", s, err)
+		return false
+	}
+	return passed(is)
+}
+
+// ValidJSON checks the provided string to determine if it is valid JSON.
+func (is *Is) ValidJSON(s string) bool {
+	is.TB.Helper()
+	if err := checkJSON(s); err != nil {
+		is.fail("expected %q to be valid JSON: %v", s, err)
+		return false
+	}
+	return passed(is)
+}
@@ -0,0 +1,46 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestValid(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.ValidUUID("123e4567-e89b-12d3-a456-426614174000")
+	is.ValidEmail("user@example.com")
+	is.ValidURL("https://example.com/path?q=1")
+	is.ValidIP("127.0.0.1")
+	is.ValidIP("::1")
+	is.ValidJSON(`{"a": [1, 2, null]}`)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.ValidUUID("123e4567-e89b-12d3-a456-42661417400")
+	is.ValidUUID("123e4567_e89b-12d3-a456-426614174000")
+	is.Equal(msg, `expected "123e4567_e89b-12d3-a456-426614174000" to be a valid UUID: expected '-' at position 8, got '_'`)
+	is.ValidUUID("123e4567-e89b-12d3-a456-42661417400g")
+	is.ValidEmail("user.example.com")
+	is.Equal(msg, `expected "user.example.com" to be a valid email address: missing '@'`)
+	is.ValidEmail("@example.com")
+	is.ValidEmail("User <user@example.com>")
+	is.ValidURL("example.com")
+	is.ValidURL("file:///tmp")
+	is.ValidIP("1.2.3")
+	is.ValidIP("1.2.3.256")
+	is.Equal(msg, `expected "1.2.3.256" to be a valid IP address: octet 4 ("256") is not a number between 0 and 255`)
+	is.ValidIP("::g")
+	is.ValidJSON(`{"a": }`)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 12)
+}
@@ -0,0 +1,145 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// validateTag is the struct tag holding the rules checked by ValidStruct.
+const validateTag = "validate"
+
+// checkRule returns a description of how the value v violates the
+// validation rule, or "" if it does not.
+func checkRule(v reflect.Value, rule string) string {
+	name, param, _ := strings.Cut(rule, "=")
+	switch name {
+	case "required":
+		if isZero(v.Interface()) {
+			return "is required"
+		}
+		return ""
+	case "oneof":
+		s := fmt.Sprint(v.Interface())
+		for _, option := range strings.Fields(param) {
+			if s == option {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of [%s]", s, param)
+	case "min", "max":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return fmt.Sprintf("invalid rule %q: %v", rule, err)
+		}
+		n, what, ok := ruleMeasure(v)
+		if !ok {
+			return fmt.Sprintf("rule %q does not apply to '%s'", rule, v.Type())
+		}
+		if name == "min" && n < limit {
+			return fmt.Sprintf("%s %v is less than min %v", what, n, limit)
+		}
+		if name == "max" && n > limit {
+			return fmt.Sprintf("%s %v is more than max %v", what, n, limit)
+		}
+		return ""
+	}
+	return fmt.Sprintf("unknown rule %q", rule)
+}
+
+// ruleMeasure returns the number compared by the min and max rules for v:
+// its value for numbers, and its length for strings and collections.
+func ruleMeasure(v reflect.Value) (n float64, what string, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "value", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), "value", true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "value", true
+	case reflect.String:
+		return float64(len([]rune(v.String()))), "length", true
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return float64(v.Len()), "length", true
+	}
+	return 0, "", false
+}
+
+// structViolations appends the violations of the validation rules of the
+// exported fields of the struct v, and of the structs nested in it, to
+// violations, with the field paths prefixed with path. visiting holds the
+// pointers followed to reach v, so that a pointer back to one of them is
+// not followed again.
+func structViolations(v reflect.Value, path string, visiting map[visitKey]bool, violations []string) []string {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		fieldPath := path + field.Name
+		if tag := field.Tag.Get(validateTag); tag != "" && tag != "-" {
+			for _, rule := range strings.Split(tag, ",") {
+				if problem := checkRule(fv, strings.TrimSpace(rule)); problem != "" {
+					violations = append(violations, fieldPath+": "+problem)
+				}
+			}
+		}
+		if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			key := visitKey{ptr: fv.Pointer(), typ: fv.Type()}
+			if visiting[key] {
+				continue
+			}
+			visiting[key] = true
+			if elem := fv.Elem(); elem.Kind() == reflect.Struct {
+				violations = structViolations(elem, fieldPath+".", visiting, violations)
+			}
+			delete(visiting, key)
+			continue
+		}
+		if fv.Kind() == reflect.Struct {
+			violations = structViolations(fv, fieldPath+".", visiting, violations)
+		}
+	}
+	return violations
+}
+
+// ValidStruct checks the provided struct, or pointer to struct, against the
+// rules in the `validate` tags of its exported fields and of the structs
+// nested in it, and reports all violations in one failure. The rules are
+// separated by commas:
+//
+//   - required: the field is not zero, see Zero
+//   - min=N, max=N: numbers are at least or at most N, and strings and
+//     collections have at least or at most N elements
+//   - oneof=a b c: the field, formatted with fmt.Sprint, is one of the
+//     space-separated options
+//
+// For example:
+//
+//	type Config struct {
+//		Host string `validate:"required"`
+//		Port int    `validate:"min=1,max=65535"`
+//		Mode string `validate:"oneof=dev prod"`
+//	}
+func (is *Is) ValidStruct(v interface{}) bool {
+	is.TB.Helper()
+	rv := reflect.ValueOf(v)
+	visiting := map[visitKey]bool{}
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		visiting[visitKey{ptr: rv.Pointer(), typ: rv.Type()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		is.fail("expected a struct, but got '%s'", objectTypeName(v))
+		return false
+	}
+	violations := structViolations(rv, "", visiting, nil)
+	if len(violations) > 0 {
+		is.fail("struct '%s' is invalid:\n\t%s", rv.Type(), strings.Join(violations, "\n\t"))
+		return false
+	}
+	return passed(is)
+}
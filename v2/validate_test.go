@@ -0,0 +1,87 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+type validAddress struct {
+	City string `validate:"required"`
+	Zip  string `validate:"min=5,max=5"`
+}
+
+type validConfig struct {
+	Host    string   `validate:"required"`
+	Port    int      `validate:"min=1,max=65535"`
+	Mode    string   `validate:"oneof=dev prod"`
+	Tags    []string `validate:"max=2"`
+	Ratio   float64  `validate:"max=1"`
+	Address *validAddress
+	Backup  validAddress
+	hidden  string `validate:"required"`
+}
+
+type validNode struct {
+	Name string `validate:"required"`
+	Next *validNode
+}
+
+func TestValidStruct(t *testing.T) {
+	is := New(t)
+
+	valid := validConfig{
+		Host:    "localhost",
+		Port:    8080,
+		Mode:    "dev",
+		Ratio:   0.5,
+		Address: &validAddress{City: "Paris", Zip: "75001"},
+		Backup:  validAddress{City: "Lyon", Zip: "69001"},
+	}
+	is.ValidStruct(valid)
+	is.ValidStruct(&valid)
+
+	hit := 0
+	var msgs []string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	})
+	is.ValidStruct(validConfig{
+		Port:    70000,
+		Mode:    "test",
+		Tags:    []string{"a", "b", "c"},
+		Ratio:   1.5,
+		Address: &validAddress{Zip: "123"},
+	})
+	is.ValidStruct(struct {
+		N bool `validate:"min=1,email"`
+	}{})
+	is.ValidStruct("config")
+	n := &validNode{}
+	n.Next = &validNode{Name: "b", Next: n}
+	is.ValidStruct(n)
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 4)
+	is.Equal(msgs[0], `struct 'is.validConfig' is invalid:
+	Host: is required
+	Port: value 70000 is more than max 65535
+	Mode: "test" is not one of [dev prod]
+	Tags: length 3 is more than max 2
+	Ratio: value 1.5 is more than max 1
+	Address.City: is required
+	Address.Zip: length 3 is less than min 5
+	Backup.City: is required
+	Backup.Zip: length 0 is less than min 5`)
+	is.Equal(msgs[1], `struct 'struct { N bool "validate:\"min=1,email\"" }' is invalid:
+	N: rule "min=1" does not apply to 'bool'
+	N: unknown rule "email"`)
+	is.Equal(msgs[2], "expected a struct, but got 'string'")
+	is.Equal(msgs[3], `struct 'is.validNode' is invalid:
+	Name: is required`)
+
+	// a pointer back to an enclosing struct is not followed again
+	self := &validNode{Name: "a"}
+	self.Next = self
+	is.ValidStruct(self)
+	is.ValidStruct(*self)
+}
@@ -0,0 +1,75 @@
+package is
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// verbosityEnv is the environment variable setting the default verbosity
+// of failure messages, as a number: 0 for VerbosityQuiet, 1 for
+// VerbosityNormal and 2 for VerbosityVerbose, for example IS_VERBOSE=2.
+const verbosityEnv = "IS_VERBOSE"
+
+// Verbosity controls how much detail failure messages include. The zero
+// value is VerbosityNormal, so that an Is not created with New, such as
+// &is.Is{TB: t}, prints full failure messages.
+type Verbosity int
+
+const (
+	// VerbosityQuiet prints only the first line of failure messages.
+	VerbosityQuiet Verbosity = -1
+	// VerbosityNormal prints failure messages with the differences found,
+	// the added messages and the context. It is the default.
+	VerbosityNormal Verbosity = 0
+	// VerbosityVerbose also prints the full actual and expected values of
+	// failing comparisons with their types, ignoring FormatLimits.
+	VerbosityVerbose Verbosity = 1
+)
+
+// EnvVerbosity returns the verbosity set with the IS_VERBOSE environment
+// variable, which New uses by default: 0 is VerbosityQuiet, 1 is
+// VerbosityNormal and 2 is VerbosityVerbose. Values below 0 or above 2 are
+// treated as VerbosityQuiet or VerbosityVerbose. If the variable is not set
+// or is not a number, VerbosityNormal is returned.
+func EnvVerbosity() Verbosity {
+	level, err := strconv.Atoi(os.Getenv(verbosityEnv))
+	if err != nil {
+		return VerbosityNormal
+	}
+	// the variable counts from 0 for VerbosityQuiet
+	v := Verbosity(level) + VerbosityQuiet
+	switch {
+	case level < 0:
+		return VerbosityQuiet
+	case v > VerbosityVerbose:
+		return VerbosityVerbose
+	}
+	return v
+}
+
+// WithVerbosity returns a copy of this instance of Is which prints failure
+// messages with the given verbosity, instead of the one set with the
+// IS_VERBOSE environment variable. This lets CI print full values while
+// local runs stay terse, or the other way around.
+func (is *Is) WithVerbosity(level Verbosity) *Is {
+	newIs := *is
+	newIs.verbosity = level
+	return &newIs
+}
+
+// applyVerbosity adapts the failure message msg to the verbosity of is.
+func (is *Is) applyVerbosity(msg string) string {
+	switch {
+	case is.verbosity <= VerbosityQuiet:
+		if i := strings.IndexByte(msg, '\n'); i >= 0 {
+			msg = msg[:i]
+		}
+	case is.verbosity >= VerbosityVerbose && is.values != nil:
+		msg += fmt.Sprintf("\n\tactual: %s (%s)\n\texpected: %s (%s)",
+			formatValue(is.values.actual), objectTypeName(is.values.actual),
+			formatValue(is.values.expected), objectTypeName(is.values.expected))
+	}
+	return msg
+}
@@ -0,0 +1,48 @@
+package is
+
+import (
+	"testing"
+
+	"github.com/ilius/is/v2/istest"
+)
+
+func TestVerbosity(t *testing.T) {
+	is := New(t)
+
+	c := NewCollector()
+	ci := c.WithContext("id", 7)
+	ci.WithVerbosity(VerbosityQuiet).Equal([]int{1, 2}, []int{1, 3})
+	ci.Equal(1, 2)
+	ci.WithVerbosity(VerbosityVerbose).FormatLimits(0, 1).Equal([]int{1, 2}, []int{1, 3})
+	errs := c.Errs()
+	is.Len(errs, 3)
+	is.ErrMsg(errs[0], "[Equal] objects of type '[]int' differ at [1]: got 2, want 3")
+	is.ErrMsg(errs[1], "[Equal] got '1' (int). expected '2' (int)\n\tid: 7")
+	is.ErrMsg(errs[2], "[Equal] objects of type '[]int' differ at [1]: got 2, want 3\n\tid: 7"+
+		"\n\tactual: [1 2] ([]int)\n\texpected: [1 3] ([]int)")
+
+	t.Setenv(verbosityEnv, "0")
+	is.Equal(EnvVerbosity(), VerbosityQuiet)
+	is.Equal(New(t).verbosity, VerbosityQuiet)
+	t.Setenv(verbosityEnv, "1")
+	is.Equal(EnvVerbosity(), VerbosityNormal)
+	t.Setenv(verbosityEnv, "2")
+	is.Equal(EnvVerbosity(), VerbosityVerbose)
+	t.Setenv(verbosityEnv, "-3")
+	is.Equal(EnvVerbosity(), VerbosityQuiet)
+	t.Setenv(verbosityEnv, "5")
+	is.Equal(EnvVerbosity(), VerbosityVerbose)
+	t.Setenv(verbosityEnv, "x")
+	is.Equal(EnvVerbosity(), VerbosityNormal)
+}
+
+func TestVerbosityZeroValue(t *testing.T) {
+	is := New(t)
+
+	is.Equal(Verbosity(0), VerbosityNormal)
+	tb := istest.Run(func(tb *istest.TB) {
+		lit := &Is{TB: tb}
+		lit.WithContext("id", 7).Equal(1, 2)
+	})
+	is.Equal(tb.Errors(), []string{"[Equal] got '1' (int). expected '2' (int)\n\tid: 7"})
+}
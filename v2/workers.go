@@ -3,7 +3,11 @@ package is
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
 )
 
 func objectTypeName(o interface{}) string {
@@ -31,81 +35,374 @@ func isNil(o interface{}) bool {
 		return true
 	}
 	value := reflect.ValueOf(o)
-	kind := value.Kind()
-	if kind >= reflect.Chan &&
-		kind <= reflect.Slice &&
-		value.IsNil() {
-		return true
+	switch value.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return value.IsNil()
 	}
 	return false
 }
 
+// typedNilString formats a typed nil value like Go source, for example
+// "(*MyErr)(nil)".
+func typedNilString(o interface{}) string {
+	return fmt.Sprintf("(%T)(nil)", o)
+}
+
+// typedNilDetails returns a note for failure messages if o is a non-nil
+// interface containing a typed nil, or an empty string otherwise.
+func typedNilDetails(o interface{}) string {
+	if o == nil || !isNil(o) {
+		return ""
+	}
+	return fmt.Sprintf(", but got a non-nil interface containing typed nil %s", typedNilString(o))
+}
+
+// isZero reports whether o is the zero value of its type. Nil pointers,
+// and pointers to a zero value, are zero. Empty slices, maps and channels
+// are zero. Values with an IsZero method, such as time.Time, are zero if it
+// returns true. Other values are compared to the zero value of their type
+// like with Equal, so types implementing Equaler are respected.
 func isZero(o interface{}) bool {
 	if o == nil {
 		return true
 	}
 	v := reflect.ValueOf(o)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return true
+	}
+	if z, ok := o.(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
 	switch v.Kind() {
 	case reflect.Ptr:
-		return reflect.DeepEqual(o,
-			reflect.New(v.Type().Elem()).Interface())
-	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
-		if v.Len() == 0 {
+		return isZero(v.Elem().Interface())
+	case reflect.Slice, reflect.Map, reflect.Chan:
+		return v.Len() == 0
+	case reflect.Func, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return isEqual(o, reflect.Zero(v.Type()).Interface(), equalOptions{})
+}
+
+// objectLen returns the length of the provided object and whether the
+// object has a length at all. Strings, arrays, slices, maps and channels
+// have a length.
+func objectLen(o interface{}) (int, bool) {
+	if o == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(o)
+	switch v.Kind() {
+	case reflect.String, reflect.Array, reflect.Slice, reflect.Map, reflect.Chan:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// isEmpty reports whether the provided object is empty. Objects with a length
+// are empty if the length is 0, pointers are empty if they are nil or point
+// to an empty object, and anything else is empty if it is the zero value.
+// Pointers leading back to themselves, such as p in "p.Next = p", are not
+// empty.
+func isEmpty(o interface{}) bool {
+	var seen map[uintptr]bool
+	for {
+		if o == nil {
 			return true
 		}
-		return false
-	default:
-		return reflect.DeepEqual(o,
-			reflect.Zero(v.Type()).Interface())
+		if l, ok := objectLen(o); ok {
+			return l == 0
+		}
+		v := reflect.ValueOf(o)
+		if v.Kind() != reflect.Ptr {
+			return isZero(o)
+		}
+		if v.IsNil() {
+			return true
+		}
+		if seen[v.Pointer()] {
+			return false
+		}
+		if seen == nil {
+			seen = map[uintptr]bool{}
+		}
+		seen[v.Pointer()] = true
+		o = v.Elem().Interface()
 	}
 }
 
-func isEqual(a interface{}, b interface{}) bool {
+func isEqual(a interface{}, b interface{}, opts equalOptions) bool {
+	equal, _ := compareObjects(a, b, opts, formatOptions{})
+	return equal
+}
+
+// compareObjects compares a and b like isEqual, and also returns the
+// comparer used for a deep compare, if any, for failure details, which are
+// rendered with fopts.
+func compareObjects(a interface{}, b interface{}, opts equalOptions, fopts formatOptions) (bool, *comparer) {
+	if opts.equateEmpty && reflect.TypeOf(a) == reflect.TypeOf(b) &&
+		isEmptyCollection(reflect.ValueOf(a)) && isEmptyCollection(reflect.ValueOf(b)) {
+		return true, nil
+	}
+
 	if isNil(a) || isNil(b) {
 		if isNil(a) && !isNil(b) {
-			return false
+			return false, nil
 		}
 		if !isNil(a) && isNil(b) {
-			return false
+			return false, nil
 		}
-		return a == b
+		return a == b, nil
 	}
 
 	// Call a.Equaler if it is implemented
 	if e, ok := a.(Equaler); ok {
-		return e.Equal(b)
+		return e.Equal(b), nil
 	}
 
-	if reflect.DeepEqual(a, b) {
-		return true
+	equal, c := deepEqual(a, b, opts, fopts)
+	if equal {
+		return true, c
 	}
 
 	aValue := reflect.ValueOf(a)
 	bValue := reflect.ValueOf(b)
 	// Convert types and compare
-	if bValue.Type().ConvertibleTo(aValue.Type()) {
-		return reflect.DeepEqual(a, bValue.Convert(aValue.Type()).Interface())
+	if bValue.Type() != aValue.Type() && bValue.Type().ConvertibleTo(aValue.Type()) {
+		return deepEqual(a, bValue.Convert(aValue.Type()).Interface(), opts, fopts)
 	}
 
+	return false, c
+}
+
+// candidateEqual compares a and a candidate like isEqual, but if a does not
+// implement Equaler and the candidate does, the Equal method of the
+// candidate is used as well.
+func candidateEqual(a interface{}, candidate interface{}, opts equalOptions) bool {
+	if isEqual(a, candidate, opts) {
+		return true
+	}
+	if _, ok := a.(Equaler); ok || isNil(candidate) {
+		return false
+	}
+	if e, ok := candidate.(Equaler); ok {
+		return e.Equal(a)
+	}
 	return false
 }
 
-// fail is a function variable that is called by test functions when they
-// fail. It is overridden in test code for this package.
-var fail = failDefault
+// indexOfEqual returns the index of the first candidate equal to a, or -1.
+func indexOfEqual(a interface{}, candidates []interface{}, opts equalOptions) int {
+	for i, o := range candidates {
+		if candidateEqual(a, o, opts) {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexOfClosest returns the index of the candidate closest to a, or -1 if
+// there are no candidates. Numbers are compared by their difference,
+// strings by their edit distance and other values by the number of
+// differences found by a deep compare.
+func indexOfClosest(a interface{}, candidates []interface{}, opts equalOptions) int {
+	closest := -1
+	best := math.Inf(1)
+	for i, o := range candidates {
+		if d := distance(a, o, opts); closest < 0 || d < best {
+			closest, best = i, d
+		}
+	}
+	return closest
+}
+
+// distance returns how different b is from a, see indexOfClosest.
+func distance(a interface{}, b interface{}, opts equalOptions) float64 {
+	if isNil(a) || isNil(b) {
+		return math.Inf(1)
+	}
+	aValue := reflect.ValueOf(a)
+	bValue := reflect.ValueOf(b)
+	if bValue.Type() != aValue.Type() {
+		if !bValue.Type().ConvertibleTo(aValue.Type()) {
+			return math.Inf(1)
+		}
+		bValue = bValue.Convert(aValue.Type())
+	}
+	switch aValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return math.Abs(float64(aValue.Int()) - float64(bValue.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return math.Abs(float64(aValue.Uint()) - float64(bValue.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return math.Abs(aValue.Float() - bValue.Float())
+	case reflect.String:
+		return float64(editDistance(aValue.String(), bValue.String()))
+	}
+	equal, c := deepEqual(a, bValue.Interface(), opts, formatOptions{})
+	if equal {
+		return 0
+	}
+	if c.diffCount == 0 {
+		return 1
+	}
+	return float64(c.diffCount)
+}
+
+// editDistance returns the Levenshtein distance between a and b, in runes.
+func editDistance(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
+
+// FailFunc handles the failure of an assertion made with is. The failure
+// message is format formatted with args, as with fmt.Sprintf.
+type FailFunc func(is *Is, format string, args ...interface{})
+
+// WithFailFunc returns a copy of this instance of Is which calls f when an
+// assertion fails, instead of the default handling. The default formats the
+// message with those added with Msg and the context, and passes the failure
+// to the interceptors, the failure reports and the testing object, so f is
+// mostly useful to test assertions built on Is. A nil f restores the
+// default.
+func (is *Is) WithFailFunc(f FailFunc) *Is {
+	newIs := *is
+	newIs.failFunc = f
+	return &newIs
+}
+
+// fail is called by assertions when they fail.
+func (is *Is) fail(format string, args ...interface{}) {
+	is.TB.Helper()
+	if is.failFunc != nil {
+		is.failFunc(is, format, args...)
+		return
+	}
+	failDefault(is, format, args...)
+}
 
 // failDefault is the default failure function.
 func failDefault(is *Is, format string, args ...interface{}) {
 	is.TB.Helper()
 
-	failFmt := format
-	if len(is.failFormat) != 0 {
-		failFmt = fmt.Sprintf("%s%s%s", format, is.getMsgSep(), is.failFormat)
-		args = append(args, is.failArgs...)
+	msg := fmt.Sprintf(format, args...)
+	if m := is.message(); m != "" {
+		msg += is.getMsgSep() + m
+	}
+	if len(is.context) > 0 {
+		msg += is.contextBlock()
+	}
+	msg = is.applyVerbosity(msg)
+	if is.group != "" {
+		msg = is.group + ": " + strings.ReplaceAll(msg, "\n", "\n\t")
+	}
+	f := newFailure(is, msg)
+	if f.Assertion != "" && !is.noNames {
+		f.Message = fmt.Sprintf(is.getNameFormat(), f.Assertion) + f.Message
+	}
+	is.dispatch(AssertionResult{Assertion: f.Assertion, Caller: f.Caller, Failure: &f})
+}
+
+// assertionFrame returns the name of the outermost function of this module
+// in the current call stack, which is the assertion called by the test, and
+// the location it was called from. Closures are reported as the function
+// declaring them. Functions of the wrappers packages count as assertions,
+// so that their own names are reported.
+func assertionFrame() (name string, caller runtime.Frame) {
+	const module = "github.com/ilius/is/v2"
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	var stack []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		if frame.Function == module+".(*Is).fail" {
+			// Skip the FailFunc called by fail, which may be declared
+			// anywhere.
+			stack = stack[:0]
+		} else {
+			stack = append(stack, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	for _, frame := range stack {
+		inModule := strings.HasPrefix(frame.Function, module+".") ||
+			strings.HasPrefix(frame.Function, module+"/")
+		if inModule && !strings.HasSuffix(frame.File, "_test.go") {
+			name = frame.Function[strings.LastIndexByte(frame.Function, '/')+1:]
+			name = name[strings.IndexByte(name, '.')+1:]
+		} else if name != "" {
+			caller = frame
+			break
+		}
+	}
+	if strings.HasPrefix(name, "(") {
+		// Methods are reported without their receiver type.
+		name = name[strings.Index(name, ").")+2:]
+	}
+	if i := strings.IndexAny(name, "[."); i >= 0 {
+		name = name[:i]
+	}
+	return name, caller
+}
+
+// maxPanicStackFrames is the number of frames of the stack of a panic shown
+// in failure messages.
+const maxPanicStackFrames = 10
+
+// catchPanic calls f, and returns the value it panicked with and the stack
+// of the panicking goroutine, starting at the function which called panic.
+// panicked is false if f returned normally.
+func catchPanic(f func()) (value interface{}, stack string, panicked bool) {
+	defer func() {
+		if panicked {
+			value = recover()
+			stack = trimPanicStack(string(debug.Stack()))
+		}
+	}()
+	panicked = true
+	f()
+	panicked = false
+	return
+}
+
+// trimPanicStack removes the frames of the recovery and of the runtime from
+// a stack captured with debug.Stack while recovering from a panic, and keeps
+// at most maxPanicStackFrames frames.
+func trimPanicStack(stack string) string {
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "panic(") {
+			lines = lines[i+2:]
+			break
+		}
 	}
-	if is.strict {
-		is.TB.Fatalf(failFmt, args...)
-	} else {
-		is.TB.Errorf(failFmt, args...)
+	if len(lines) > 2*maxPanicStackFrames {
+		lines = append(lines[:2*maxPanicStackFrames], "...")
 	}
+	return strings.Join(lines, "\n")
 }
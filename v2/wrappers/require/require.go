@@ -0,0 +1,293 @@
+// Package require provides testify-style assertion functions backed by
+// github.com/ilius/is/v2, to ease migrating tests written for
+// github.com/stretchr/testify/require.
+//
+// Functions take the expected value before the actual one, like testify,
+// and stop the test on failure. The optional msgAndArgs are a format
+// string followed by its arguments, which are added to the failure message.
+package require
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+// asserters holds the Is used for each testing.TB, so that calls within a
+// test share one configured asserter.
+var asserters sync.Map
+
+// Use sets the Is used by the functions of this package for t, for the rest
+// of the test. This allows configuring the asserter once, for example with
+// Msg or FormatLimits, for all the assertions of a test. It is made strict
+// and bound to t.
+func Use(t testing.TB, a *is.Is) {
+	t.Helper()
+	if _, loaded := asserters.Swap(t, a.New(t).Strict()); !loaded {
+		t.Cleanup(func() { asserters.Delete(t) })
+	}
+}
+
+// asserter returns the Is for t, creating it on first use.
+func asserter(t testing.TB) *is.Is {
+	if a, ok := asserters.Load(t); ok {
+		return a.(*is.Is)
+	}
+	a, loaded := asserters.LoadOrStore(t, is.New(t).Strict())
+	if !loaded {
+		t.Cleanup(func() { asserters.Delete(t) })
+	}
+	return a.(*is.Is)
+}
+
+// messageFromMsgAndArgs builds a failure message like testify does. A
+// single argument is used as is if it is a string, and formatted with %+v
+// otherwise. A leading string followed by more arguments is used as a
+// format string. Any other arguments are formatted with %+v and joined by
+// spaces.
+func messageFromMsgAndArgs(msgAndArgs []interface{}) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+	if format, ok := msgAndArgs[0].(string); ok {
+		if len(msgAndArgs) == 1 {
+			return format
+		}
+		return fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	parts := make([]string, len(msgAndArgs))
+	for i, arg := range msgAndArgs {
+		parts[i] = fmt.Sprintf("%+v", arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// newIs returns the Is for t, with msgAndArgs added to its failure message.
+func newIs(t testing.TB, msgAndArgs []interface{}) *is.Is {
+	a := asserter(t)
+	if msg := messageFromMsgAndArgs(msgAndArgs); msg != "" {
+		a = a.AddMsg("%s", msg)
+	}
+	return a
+}
+
+// Equal asserts that expected and actual are equal.
+func Equal(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Equal(actual, expected)
+}
+
+// NotEqual asserts that expected and actual are not equal.
+func NotEqual(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).NotEqual(actual, expected)
+}
+
+// True asserts that value is true.
+func True(t testing.TB, value bool, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).True(value)
+}
+
+// False asserts that value is false.
+func False(t testing.TB, value bool, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).False(value)
+}
+
+// Nil asserts that object is nil.
+func Nil(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Nil(object)
+}
+
+// NotNil asserts that object is not nil.
+func NotNil(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).NotNil(object)
+}
+
+// NoError asserts that err is nil.
+func NoError(t testing.TB, err error, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).NotErr(err)
+}
+
+// Error asserts that err is not nil.
+func Error(t testing.TB, err error, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Err(err)
+}
+
+// EqualError asserts that err is not nil and its message is errString.
+func EqualError(t testing.TB, err error, errString string, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).ErrMsg(err, errString)
+}
+
+// ErrorContains asserts that err is not nil and its message contains
+// contains.
+func ErrorContains(t testing.TB, err error, contains string, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).ErrContains(err, contains)
+}
+
+// ErrorIs asserts that any error in the chain of err matches target.
+func ErrorIs(t testing.TB, err, target error, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).ErrIs(err, target)
+}
+
+// NotErrorIs asserts that no error in the chain of err matches target.
+func NotErrorIs(t testing.TB, err, target error, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).NotErrIs(err, target)
+}
+
+// ErrorAs asserts that any error in the chain of err can be assigned to
+// target, and sets target to it.
+func ErrorAs(t testing.TB, err error, target interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).ErrAs(err, target)
+}
+
+// Len asserts that object has length length.
+func Len(t testing.TB, object interface{}, length int, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Len(object, length)
+}
+
+// Contains asserts that the string, slice, array or map s contains the
+// substring, element or key contains.
+func Contains(t testing.TB, s, contains interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Contains(s, contains)
+}
+
+// NotContains asserts that the string, slice, array or map s does not
+// contain the substring, element or key contains.
+func NotContains(t testing.TB, s, contains interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).NotContains(s, contains)
+}
+
+// Empty asserts that object is empty.
+func Empty(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Empty(object)
+}
+
+// NotEmpty asserts that object is not empty.
+func NotEmpty(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).NotEmpty(object)
+}
+
+// Zero asserts that object is the zero value of its type.
+func Zero(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Zero(object)
+}
+
+// NotZero asserts that object is not the zero value of its type.
+func NotZero(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).NotZero(object)
+}
+
+// Greater asserts that e1 is greater than e2.
+func Greater(t testing.TB, e1, e2 interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Greater(e1, e2)
+}
+
+// Less asserts that e1 is less than e2.
+func Less(t testing.TB, e1, e2 interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Less(e1, e2)
+}
+
+// InDelta asserts that expected and actual are numbers within delta of
+// each other.
+func InDelta(t testing.TB, expected, actual interface{}, delta float64, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).InDelta(actual, expected, delta)
+}
+
+// ElementsMatch asserts that listA and listB have the same elements, the
+// same number of times, regardless of order.
+func ElementsMatch(t testing.TB, listA, listB interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).ElementsMatch(listA, listB)
+}
+
+// Subset asserts that list contains all the elements of subset.
+func Subset(t testing.TB, list, subset interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Subset(list, subset)
+}
+
+// Regexp asserts that str matches rx, which is a *regexp.Regexp or a
+// pattern string. str is formatted with fmt.Sprint if it is not a string.
+func Regexp(t testing.TB, rx interface{}, str interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	pattern, ok := rx.(string)
+	if re, isRegexp := rx.(*regexp.Regexp); isRegexp {
+		pattern, ok = re.String(), true
+	}
+	if !ok {
+		pattern = fmt.Sprint(rx)
+	}
+	newIs(t, msgAndArgs).Matches(fmt.Sprint(str), pattern)
+}
+
+// Same asserts that expected and actual are pointers of the same type to
+// the same object.
+func Same(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Same(actual, expected)
+}
+
+// IsType asserts that object has the same type as expectedType.
+func IsType(t testing.TB, expectedType, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).EqualType(expectedType, object)
+}
+
+// WithinDuration asserts that expected and actual are within delta of each
+// other.
+func WithinDuration(t testing.TB, expected, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).WithinDuration(actual, expected, delta)
+}
+
+// Eventually asserts that condition returns true within waitFor, calling
+// it every tick.
+func Eventually(t testing.TB, condition func() bool, waitFor time.Duration, tick time.Duration, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).Eventually(waitFor, tick, condition)
+}
+
+// FileExists asserts that path exists and is not a directory.
+func FileExists(t testing.TB, path string, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).FileExists(path)
+}
+
+// JSONEq asserts that expected and actual are semantically equal JSON
+// documents.
+func JSONEq(t testing.TB, expected, actual string, msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).JSONEq(actual, expected)
+}
+
+// Panics asserts that f panics.
+func Panics(t testing.TB, f func(), msgAndArgs ...interface{}) {
+	t.Helper()
+	newIs(t, msgAndArgs).ShouldPanic(f)
+}
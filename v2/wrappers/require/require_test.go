@@ -0,0 +1,181 @@
+package require
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ilius/is/v2"
+)
+
+// recorder is a testing.TB which records failures instead of reporting
+// them. Only the methods used by is are implemented.
+type recorder struct {
+	testing.TB
+	failures []string
+	cleanups []func()
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func (r *recorder) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+type codeError struct{}
+
+func (codeError) Error() string { return "code" }
+
+func TestPassing(t *testing.T) {
+	r := &recorder{}
+	errA := errors.New("a")
+	wrapped := fmt.Errorf("wrapped: %w", codeError{})
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	x := 1
+	now := time.Now()
+
+	Equal(r, 1, 1)
+	NotEqual(r, 1, 2)
+	True(r, true)
+	False(r, false)
+	Nil(r, nil)
+	NotNil(r, &x)
+	NoError(r, nil)
+	Error(r, errA)
+	EqualError(r, errA, "a")
+	ErrorContains(r, wrapped, "wrap")
+	ErrorIs(r, fmt.Errorf("x: %w", errA), errA)
+	NotErrorIs(r, wrapped, errA)
+	var ce codeError
+	ErrorAs(r, wrapped, &ce)
+	Len(r, []int{1, 2}, 2)
+	Contains(r, "hello", "ell")
+	NotContains(r, []int{1}, 2)
+	Empty(r, "")
+	NotEmpty(r, []int{1})
+	Zero(r, 0)
+	NotZero(r, 1)
+	Greater(r, 2, 1)
+	Less(r, 1, 2)
+	InDelta(r, 1.0, 1.01, 0.1)
+	ElementsMatch(r, []int{1, 2}, []int{2, 1})
+	Subset(r, []int{1, 2, 3}, []int{2})
+	Regexp(r, "^h", "hello")
+	Regexp(r, regexp.MustCompile(`\d+`), 42)
+	Same(r, &x, &x)
+	IsType(r, 0, 1)
+	WithinDuration(r, now, now.Add(time.Millisecond), time.Second)
+	Eventually(r, func() bool { return true }, time.Second, time.Millisecond)
+	FileExists(r, path)
+	JSONEq(r, `{"a": 1, "b": 2}`, `{"b": 2, "a": 1}`)
+	Panics(r, func() { panic("x") })
+
+	if len(r.failures) != 0 {
+		t.Fatalf("unexpected failures: %q", r.failures)
+	}
+}
+
+func TestFailing(t *testing.T) {
+	r := &recorder{}
+	Equal(r, 1, 2)
+	NoError(r, errors.New("a"))
+	Contains(r, []int{1}, 2)
+	Greater(r, 1, 2)
+	ElementsMatch(r, []int{1}, []int{2})
+	Regexp(r, "^x", "hello")
+	JSONEq(r, `{"a": 1}`, `{"a": 2}`)
+	Panics(r, func() {})
+	if len(r.failures) != 8 {
+		t.Fatalf("expected 8 failures, got %d: %q", len(r.failures), r.failures)
+	}
+}
+
+func TestMsgAndArgs(t *testing.T) {
+	r := &recorder{}
+	Equal(r, 1, 2, "user %d", 7)
+	if len(r.failures) != 1 || r.failures[0] != "[Equal] got '2' (int). expected '1' (int) - user 7" {
+		t.Fatalf("unexpected failures: %q", r.failures)
+	}
+}
+
+func TestFormatted(t *testing.T) {
+	r := &recorder{}
+	Equalf(r, 1, 1, "user %d", 7)
+	NoErrorf(r, nil, "user %d", 7)
+	Panicsf(r, func() { panic("x") }, "user %d", 7)
+	if len(r.failures) != 0 {
+		t.Fatalf("unexpected failures: %q", r.failures)
+	}
+	Truef(r, false, "user %d", 7)
+	if len(r.failures) != 1 || r.failures[0] != "[Truef] expected boolean to be true - user 7" {
+		t.Fatalf("unexpected failures: %q", r.failures)
+	}
+}
+
+func TestMessageFromMsgAndArgs(t *testing.T) {
+	type user struct {
+		ID int
+	}
+	tests := []struct {
+		msgAndArgs []interface{}
+		msg        string
+	}{
+		{nil, ""},
+		{[]interface{}{"100%"}, "100%"},
+		{[]interface{}{"user %d", 7}, "user 7"},
+		{[]interface{}{user{ID: 7}}, "{ID:7}"},
+		{[]interface{}{user{ID: 7}, "x", 1}, "{ID:7} x 1"},
+		{[]interface{}{nil}, "<nil>"},
+	}
+	for _, test := range tests {
+		if msg := messageFromMsgAndArgs(test.msgAndArgs); msg != test.msg {
+			t.Errorf("messageFromMsgAndArgs(%#v) = %q, expected %q", test.msgAndArgs, msg, test.msg)
+		}
+	}
+
+	r := &recorder{}
+	True(r, false, user{ID: 7})
+	if len(r.failures) != 1 || r.failures[0] != "[True] expected boolean to be true - {ID:7}" {
+		t.Fatalf("unexpected failures: %q", r.failures)
+	}
+}
+
+func TestUse(t *testing.T) {
+	r := &recorder{}
+	if asserter(r) != asserter(r) {
+		t.Fatal("expected the asserter to be reused")
+	}
+	Use(r, is.New(t).Msg("request %d", 3))
+	Equal(r, 1, 2, "user %d", 7)
+	if len(r.failures) != 1 || r.failures[0] != "[Equal] got '2' (int). expected '1' (int) - request 3 - user 7" {
+		t.Fatalf("unexpected failures: %q", r.failures)
+	}
+	if len(r.cleanups) != 1 {
+		t.Fatalf("expected 1 cleanup, got %d", len(r.cleanups))
+	}
+	r.finish()
+	if _, ok := asserters.Load(r); ok {
+		t.Fatal("expected the asserter to be removed on cleanup")
+	}
+}
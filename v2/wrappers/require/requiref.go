@@ -0,0 +1,207 @@
+package require
+
+import (
+	"testing"
+	"time"
+)
+
+// This file holds the f-suffixed variants of the assertions in require.go,
+// which take a format string and arguments instead of msgAndArgs.
+
+// Equalf is like Equal, with a formatted failure message.
+func Equalf(t testing.TB, expected, actual interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Equal(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
+// NotEqualf is like NotEqual, with a formatted failure message.
+func NotEqualf(t testing.TB, expected, actual interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	NotEqual(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
+// Truef is like True, with a formatted failure message.
+func Truef(t testing.TB, value bool, msg string, args ...interface{}) {
+	t.Helper()
+	True(t, value, append([]interface{}{msg}, args...)...)
+}
+
+// Falsef is like False, with a formatted failure message.
+func Falsef(t testing.TB, value bool, msg string, args ...interface{}) {
+	t.Helper()
+	False(t, value, append([]interface{}{msg}, args...)...)
+}
+
+// Nilf is like Nil, with a formatted failure message.
+func Nilf(t testing.TB, object interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Nil(t, object, append([]interface{}{msg}, args...)...)
+}
+
+// NotNilf is like NotNil, with a formatted failure message.
+func NotNilf(t testing.TB, object interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	NotNil(t, object, append([]interface{}{msg}, args...)...)
+}
+
+// NoErrorf is like NoError, with a formatted failure message.
+func NoErrorf(t testing.TB, err error, msg string, args ...interface{}) {
+	t.Helper()
+	NoError(t, err, append([]interface{}{msg}, args...)...)
+}
+
+// Errorf is like Error, with a formatted failure message.
+func Errorf(t testing.TB, err error, msg string, args ...interface{}) {
+	t.Helper()
+	Error(t, err, append([]interface{}{msg}, args...)...)
+}
+
+// EqualErrorf is like EqualError, with a formatted failure message.
+func EqualErrorf(t testing.TB, err error, errString string, msg string, args ...interface{}) {
+	t.Helper()
+	EqualError(t, err, errString, append([]interface{}{msg}, args...)...)
+}
+
+// ErrorContainsf is like ErrorContains, with a formatted failure message.
+func ErrorContainsf(t testing.TB, err error, contains string, msg string, args ...interface{}) {
+	t.Helper()
+	ErrorContains(t, err, contains, append([]interface{}{msg}, args...)...)
+}
+
+// ErrorIsf is like ErrorIs, with a formatted failure message.
+func ErrorIsf(t testing.TB, err, target error, msg string, args ...interface{}) {
+	t.Helper()
+	ErrorIs(t, err, target, append([]interface{}{msg}, args...)...)
+}
+
+// NotErrorIsf is like NotErrorIs, with a formatted failure message.
+func NotErrorIsf(t testing.TB, err, target error, msg string, args ...interface{}) {
+	t.Helper()
+	NotErrorIs(t, err, target, append([]interface{}{msg}, args...)...)
+}
+
+// ErrorAsf is like ErrorAs, with a formatted failure message.
+func ErrorAsf(t testing.TB, err error, target interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	ErrorAs(t, err, target, append([]interface{}{msg}, args...)...)
+}
+
+// Lenf is like Len, with a formatted failure message.
+func Lenf(t testing.TB, object interface{}, length int, msg string, args ...interface{}) {
+	t.Helper()
+	Len(t, object, length, append([]interface{}{msg}, args...)...)
+}
+
+// Containsf is like Contains, with a formatted failure message.
+func Containsf(t testing.TB, s, contains interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Contains(t, s, contains, append([]interface{}{msg}, args...)...)
+}
+
+// NotContainsf is like NotContains, with a formatted failure message.
+func NotContainsf(t testing.TB, s, contains interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	NotContains(t, s, contains, append([]interface{}{msg}, args...)...)
+}
+
+// Emptyf is like Empty, with a formatted failure message.
+func Emptyf(t testing.TB, object interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Empty(t, object, append([]interface{}{msg}, args...)...)
+}
+
+// NotEmptyf is like NotEmpty, with a formatted failure message.
+func NotEmptyf(t testing.TB, object interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	NotEmpty(t, object, append([]interface{}{msg}, args...)...)
+}
+
+// Zerof is like Zero, with a formatted failure message.
+func Zerof(t testing.TB, object interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Zero(t, object, append([]interface{}{msg}, args...)...)
+}
+
+// NotZerof is like NotZero, with a formatted failure message.
+func NotZerof(t testing.TB, object interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	NotZero(t, object, append([]interface{}{msg}, args...)...)
+}
+
+// Greaterf is like Greater, with a formatted failure message.
+func Greaterf(t testing.TB, e1, e2 interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Greater(t, e1, e2, append([]interface{}{msg}, args...)...)
+}
+
+// Lessf is like Less, with a formatted failure message.
+func Lessf(t testing.TB, e1, e2 interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Less(t, e1, e2, append([]interface{}{msg}, args...)...)
+}
+
+// InDeltaf is like InDelta, with a formatted failure message.
+func InDeltaf(t testing.TB, expected, actual interface{}, delta float64, msg string, args ...interface{}) {
+	t.Helper()
+	InDelta(t, expected, actual, delta, append([]interface{}{msg}, args...)...)
+}
+
+// ElementsMatchf is like ElementsMatch, with a formatted failure message.
+func ElementsMatchf(t testing.TB, listA, listB interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	ElementsMatch(t, listA, listB, append([]interface{}{msg}, args...)...)
+}
+
+// Subsetf is like Subset, with a formatted failure message.
+func Subsetf(t testing.TB, list, subset interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Subset(t, list, subset, append([]interface{}{msg}, args...)...)
+}
+
+// Regexpf is like Regexp, with a formatted failure message.
+func Regexpf(t testing.TB, rx interface{}, str interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Regexp(t, rx, str, append([]interface{}{msg}, args...)...)
+}
+
+// Samef is like Same, with a formatted failure message.
+func Samef(t testing.TB, expected, actual interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	Same(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
+// IsTypef is like IsType, with a formatted failure message.
+func IsTypef(t testing.TB, expectedType, object interface{}, msg string, args ...interface{}) {
+	t.Helper()
+	IsType(t, expectedType, object, append([]interface{}{msg}, args...)...)
+}
+
+// WithinDurationf is like WithinDuration, with a formatted failure message.
+func WithinDurationf(t testing.TB, expected, actual time.Time, delta time.Duration, msg string, args ...interface{}) {
+	t.Helper()
+	WithinDuration(t, expected, actual, delta, append([]interface{}{msg}, args...)...)
+}
+
+// Eventuallyf is like Eventually, with a formatted failure message.
+func Eventuallyf(t testing.TB, condition func() bool, waitFor time.Duration, tick time.Duration, msg string, args ...interface{}) {
+	t.Helper()
+	Eventually(t, condition, waitFor, tick, append([]interface{}{msg}, args...)...)
+}
+
+// FileExistsf is like FileExists, with a formatted failure message.
+func FileExistsf(t testing.TB, path string, msg string, args ...interface{}) {
+	t.Helper()
+	FileExists(t, path, append([]interface{}{msg}, args...)...)
+}
+
+// JSONEqf is like JSONEq, with a formatted failure message.
+func JSONEqf(t testing.TB, expected, actual string, msg string, args ...interface{}) {
+	t.Helper()
+	JSONEq(t, expected, actual, append([]interface{}{msg}, args...)...)
+}
+
+// Panicsf is like Panics, with a formatted failure message.
+func Panicsf(t testing.TB, f func(), msg string, args ...interface{}) {
+	t.Helper()
+	Panics(t, f, append([]interface{}{msg}, args...)...)
+}
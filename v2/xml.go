@@ -0,0 +1,359 @@
+package is
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// xmlNode is an element or, if name.Local is empty, a text node of a parsed
+// XML document. Comments, processing instructions and whitespace-only text
+// are dropped, and remaining text is trimmed.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     string
+}
+
+// parseXML parses the provided document and returns its root element.
+// Attributes are sorted by name and namespace declarations are dropped, so
+// documents can be compared regardless of attribute order and prefixes.
+func parseXML(doc string) (*xmlNode, error) {
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name}
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+					continue
+				}
+				n.attrs = append(n.attrs, a)
+			}
+			sort.Slice(n.attrs, func(i, j int) bool {
+				a, b := n.attrs[i].Name, n.attrs[j].Name
+				if a.Space != b.Space {
+					return a.Space < b.Space
+				}
+				return a.Local < b.Local
+			})
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			text := strings.TrimSpace(string(t))
+			if text != "" {
+				parent.children = append(parent.children, &xmlNode{text: text})
+			}
+		}
+	}
+	var elems []*xmlNode
+	for _, c := range root.children {
+		if c.name.Local != "" {
+			elems = append(elems, c)
+		}
+	}
+	if len(elems) != 1 {
+		return nil, fmt.Errorf("expected exactly one root element, got %d", len(elems))
+	}
+	return elems[0], nil
+}
+
+// stringValue returns the concatenated text of the node and its descendants.
+func (n *xmlNode) stringValue() string {
+	if n.name.Local == "" {
+		return n.text
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		b.WriteString(c.stringValue())
+	}
+	return b.String()
+}
+
+func (n *xmlNode) attr(local string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Local == local {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+func xmlNameString(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// xmlDiff returns a description of the first difference between the two
+// nodes, or an empty string if they are equal.
+func xmlDiff(at string, a *xmlNode, e *xmlNode) string {
+	if a.name.Local == "" || e.name.Local == "" {
+		if a.name.Local != "" || e.name.Local != "" || a.text != e.text {
+			return fmt.Sprintf("%s: got %s, expected %s", at, xmlNodeSummary(a), xmlNodeSummary(e))
+		}
+		return ""
+	}
+	if a.name != e.name {
+		return fmt.Sprintf("%s: got element <%s>, expected <%s>", at, xmlNameString(a.name), xmlNameString(e.name))
+	}
+	for i := 0; i < len(a.attrs) || i < len(e.attrs); i++ {
+		switch {
+		case i >= len(a.attrs):
+			return fmt.Sprintf("%s: missing attribute %s", at, xmlNameString(e.attrs[i].Name))
+		case i >= len(e.attrs):
+			return fmt.Sprintf("%s: unexpected attribute %s", at, xmlNameString(a.attrs[i].Name))
+		case a.attrs[i].Name != e.attrs[i].Name:
+			return fmt.Sprintf("%s: got attribute %s, expected %s", at, xmlNameString(a.attrs[i].Name), xmlNameString(e.attrs[i].Name))
+		case a.attrs[i].Value != e.attrs[i].Value:
+			return fmt.Sprintf("%s/@%s: got %q, expected %q", at, xmlNameString(a.attrs[i].Name), a.attrs[i].Value, e.attrs[i].Value)
+		}
+	}
+	for i := 0; i < len(a.children) || i < len(e.children); i++ {
+		path := fmt.Sprintf("%s/node()[%d]", at, i+1)
+		switch {
+		case i >= len(a.children):
+			return fmt.Sprintf("%s: missing %s", path, xmlNodeSummary(e.children[i]))
+		case i >= len(e.children):
+			return fmt.Sprintf("%s: unexpected %s", path, xmlNodeSummary(a.children[i]))
+		}
+		if c := a.children[i]; c.name.Local != "" {
+			path = fmt.Sprintf("%s/%s[%d]", at, c.name.Local, a.elementIndex(i))
+		}
+		if d := xmlDiff(path, a.children[i], e.children[i]); d != "" {
+			return d
+		}
+	}
+	return ""
+}
+
+// elementIndex returns the 1-based position of the i-th child among the
+// children with the same name, as used in paths.
+func (n *xmlNode) elementIndex(i int) int {
+	index := 0
+	for _, c := range n.children[:i+1] {
+		if c.name == n.children[i].name {
+			index++
+		}
+	}
+	return index
+}
+
+func xmlNodeSummary(n *xmlNode) string {
+	if n.name.Local == "" {
+		return fmt.Sprintf("text %q", n.text)
+	}
+	return fmt.Sprintf("element <%s>", xmlNameString(n.name))
+}
+
+// xpathStep is one step of a path, such as "item[2]" or "item[@id='1']".
+type xpathStep struct {
+	descendant bool
+	name       string
+	index      int
+	attrName   string
+	attrValue  string
+}
+
+func (s xpathStep) matches(n *xmlNode) bool {
+	if n.name.Local == "" || (s.name != "*" && s.name != n.name.Local) {
+		return false
+	}
+	if s.attrName != "" {
+		v, ok := n.attr(s.attrName)
+		return ok && v == s.attrValue
+	}
+	return true
+}
+
+// parseXPath parses the supported subset of XPath: absolute or relative
+// location paths made of element names or "*", optionally preceded by "//"
+// for descendants and followed by a position "[n]" or an attribute
+// predicate "[@name='value']". The last step may also be "@name" or
+// "text()" to select an attribute or the text of the matched element.
+func parseXPath(path string) (steps []xpathStep, last string, err error) {
+	p := strings.TrimPrefix(path, "/")
+	descendant := false
+	if strings.HasPrefix(path, "//") {
+		p = strings.TrimPrefix(p, "/")
+		descendant = true
+	}
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		if part == "" {
+			if i == len(parts)-1 {
+				return nil, "", fmt.Errorf("invalid path %q: trailing '/'", path)
+			}
+			descendant = true
+			continue
+		}
+		if i == len(parts)-1 && (strings.HasPrefix(part, "@") || part == "text()") {
+			last = part
+			break
+		}
+		step := xpathStep{descendant: descendant, name: part}
+		descendant = false
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, "", fmt.Errorf("invalid path %q: missing ']' in %q", path, part)
+			}
+			step.name = part[:open]
+			pred := part[open+1 : len(part)-1]
+			if strings.HasPrefix(pred, "@") {
+				eq := strings.IndexByte(pred, '=')
+				if eq < 0 {
+					return nil, "", fmt.Errorf("invalid path %q: expected [@name='value'] in %q", path, part)
+				}
+				step.attrName = pred[1:eq]
+				step.attrValue = strings.Trim(pred[eq+1:], `'"`)
+			} else {
+				n, err := strconv.Atoi(pred)
+				if err != nil || n < 1 {
+					return nil, "", fmt.Errorf("invalid path %q: invalid position in %q", path, part)
+				}
+				step.index = n
+			}
+		}
+		if colon := strings.IndexByte(step.name, ':'); colon >= 0 {
+			step.name = step.name[colon+1:]
+		}
+		steps = append(steps, step)
+	}
+	return steps, last, nil
+}
+
+func xpathDescendants(n *xmlNode, out []*xmlNode) []*xmlNode {
+	for _, c := range n.children {
+		if c.name.Local != "" {
+			out = append(out, c)
+			out = xpathDescendants(c, out)
+		}
+	}
+	return out
+}
+
+// evalXPath returns the string values selected by the path in the document
+// whose root element is root.
+func evalXPath(root *xmlNode, path string) ([]string, error) {
+	steps, last, err := parseXPath(path)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []*xmlNode{{children: []*xmlNode{root}}}
+	for _, step := range steps {
+		var next []*xmlNode
+		for _, n := range nodes {
+			// "//" selects children of the node or of any of its descendants,
+			// so positions are counted among siblings as in XPath
+			contexts := []*xmlNode{n}
+			if step.descendant {
+				contexts = xpathDescendants(n, contexts)
+			}
+			for _, ctx := range contexts {
+				count := 0
+				for _, c := range ctx.children {
+					if !step.matches(c) {
+						continue
+					}
+					count++
+					if step.index == 0 || step.index == count {
+						next = append(next, c)
+					}
+				}
+			}
+		}
+		nodes = next
+	}
+	values := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		switch {
+		case strings.HasPrefix(last, "@"):
+			if v, ok := n.attr(last[1:]); ok {
+				values = append(values, v)
+			}
+		case last == "text()":
+			for _, c := range n.children {
+				if c.name.Local == "" {
+					values = append(values, c.text)
+				}
+			}
+		default:
+			values = append(values, n.stringValue())
+		}
+	}
+	return values, nil
+}
+
+// XMLEq checks the provided XML documents to determine if they are
+// equivalent, ignoring attribute order, namespace prefixes, comments and
+// whitespace around text. The path of the first difference is reported on
+// failure.
+func (is *Is) XMLEq(actual string, expected string) bool {
+	is.TB.Helper()
+	a, err := parseXML(actual)
+	if err != nil {
+		is.fail("expected actual object to be XML: %v", err)
+		return false
+	}
+	e, err := parseXML(expected)
+	if err != nil {
+		is.fail("expected object to be XML: %v", err)
+		return false
+	}
+	at := "/" + a.name.Local
+	if d := xmlDiff(at, a, e); d != "" {
+		is.fail("XML documents differ at %s", d)
+		return false
+	}
+	return passed(is)
+}
+
+// XPath checks the provided XML document to determine if the first value
+// selected by path is equal to expected. The value of an element is its
+// text, including the text of its descendants.
+//
+// A subset of XPath is supported: steps made of element names or "*",
+// separated by "/" or "//" for descendants, each optionally followed by a
+// position "[n]" or an attribute predicate "[@name='value']", and a last
+// step which may be "@name" or "text()". For example:
+//
+//	is.XPath(doc, "//order[@id='7']/item[2]/@sku", "A-42")
+func (is *Is) XPath(doc string, path string, expected string) bool {
+	is.TB.Helper()
+	root, err := parseXML(doc)
+	if err != nil {
+		is.fail("expected object to be XML: %v", err)
+		return false
+	}
+	values, err := evalXPath(root, path)
+	if err != nil {
+		is.fail("%v", err)
+		return false
+	}
+	if len(values) == 0 {
+		is.fail("expected %q at %s, but nothing matched", expected, path)
+		return false
+	}
+	if values[0] != expected {
+		is.fail("got %q at %s. expected %q", values[0], path, expected)
+		return false
+	}
+	return passed(is)
+}
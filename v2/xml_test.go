@@ -0,0 +1,80 @@
+package is
+
+import (
+	"fmt"
+	"testing"
+)
+
+const testXML = `<?xml version="1.0"?>
+<orders xmlns:p="urn:p">
+	<!-- first order -->
+	<order id="7" status="open">
+		<item sku="A-1">pen</item>
+		<item sku="A-42">ink <b>blue</b></item>
+	</order>
+	<order id="8" status="closed"/>
+</orders>`
+
+func TestXMLEq(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.XMLEq(testXML, `<orders><order status="open" id="7"><item sku="A-1"> pen </item><item sku="A-42">ink<b>blue</b></item></order><order status="closed" id="8"></order></orders>`)
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.XMLEq(testXML, `<orders><order status="open" id="7"><item sku="A-1">pen</item><item sku="A-43">ink<b>blue</b></item></order><order status="closed" id="8"/></orders>`)
+	is.Equal(msg, `XML documents differ at /orders/order[1]/item[2]/@sku: got "A-42", expected "A-43"`)
+	is.XMLEq(testXML, `<orders><order status="open" id="7"><item sku="A-1">pen</item><item sku="A-42">ink<b>blue</b></item></order></orders>`)
+	is.Equal(msg, `XML documents differ at /orders/node()[2]: unexpected element <order>`)
+	is.XMLEq(`<a>x</a>`, `<a><b/></a>`)
+	is.XMLEq(`<a x="1"/>`, `<a/>`)
+	is.XMLEq(`<a/>`, `<b/>`)
+	is.XMLEq(`<a>`, `<a/>`)
+	is.XMLEq(`<a/><b/>`, `<a/>`)
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 7)
+}
+
+func TestXPath(t *testing.T) {
+	is := New(t)
+
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		fmt.Print(fmt.Sprintf(format, args...))
+		t.FailNow()
+	})
+	is.XPath(testXML, "/orders/order[1]/@status", "open")
+	is.XPath(testXML, "//order[@id='8']/@status", "closed")
+	is.XPath(testXML, "//item[2]/@sku", "A-42")
+	is.XPath(testXML, "/orders/order/item[2]", "inkblue")
+	is.XPath(testXML, "/orders/order/item[2]/text()", "ink")
+	is.XPath(testXML, "//b", "blue")
+	is.XPath(testXML, "/orders/*[2]/@id", "8")
+	is.XPath(testXML, "orders//item/@sku", "A-1")
+
+	hit := 0
+	var msg string
+	is = is.WithFailFunc(func(is *Is, format string, args ...interface{}) {
+		hit++
+		msg = fmt.Sprintf(format, args...)
+	})
+	is.XPath(testXML, "//item[1]", "ink")
+	is.Equal(msg, `got "pen" at //item[1]. expected "ink"`)
+	is.XPath(testXML, "//item[3]", "ink")
+	is.Equal(msg, `expected "ink" at //item[3], but nothing matched`)
+	is.XPath(testXML, "//item[x]", "ink")
+	is.XPath(testXML, "//item[@sku]", "ink")
+	is.XPath(testXML, "//item/", "ink")
+	is.XPath(`<a>`, "a", "")
+
+	is = is.WithFailFunc(nil)
+	is.Strict().Equal(hit, 6)
+}